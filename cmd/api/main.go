@@ -1,23 +1,318 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // 実行環境にタイムゾーンデータがなくても IANA タイムゾーンを読み込めるようにする
 
+	"morning-call/internal/domain"
 	"morning-call/internal/handler"
+	"morning-call/internal/infrastructure/notifier"
+	"morning-call/internal/infrastructure/persistence/filestore"
 	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+	"morning-call/internal/shared/clock"
 	"morning-call/internal/usecase"
+	"morning-call/internal/worker"
+)
+
+const (
+	sessionTTL       = 24 * time.Hour
+	dispatchInterval = 5 * time.Second
+	notifyTimeout    = 10 * time.Second
+	ackWindow        = 15 * time.Minute
+	relayInterval    = time.Second
+	shutdownTimeout  = 10 * time.Second
+
+	// 繰り返し設定は配信の少し前までに個別のモーニングコールとして生成しておく
+	materializeInterval = time.Minute
+	seriesHorizon       = 48 * time.Hour
+
+	// 削除したモーニングコールは復元期間 (domain.RestoreWindow) を過ぎても一定期間残してからパージする
+	purgeInterval  = time.Hour
+	purgeRetention = 30 * 24 * time.Hour
+
+	// 応答のないフレンド申請は有効期限 (FRIEND_REQUEST_TTL) を過ぎると期限切れにする
+	friendRequestExpireInterval = time.Hour
+	defaultFriendRequestTTL     = 30 * 24 * time.Hour
 )
 
 func main() {
-	userRepo := inmemory.NewInMemoryUserRepository()
-	userUsecase := usecase.NewUserUsecase(userRepo)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repos, err := newRepositories()
+	if err != nil {
+		log.Fatalf("could not open repositories %v", err)
+	}
+	userRepo := repos.user
+	sessionRepo := repos.session
+	morningCallRepo := repos.morningCall
+	relationshipRepo := repos.relationship
+	seriesRepo := repos.series
+	outboxRepo := repos.outbox
+	auditRepo := repos.audit
+	txManager := repos.tx
+
+	// 旧フレンド関係 (User.RelatedUsers) を Relationship に移行する
+	migrated, err := usecase.MigrateRelatedUsers(ctx, userRepo, relationshipRepo, txManager)
+	if err != nil {
+		log.Fatalf("could not migrate related users %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migrated %d related users to relationships", migrated)
+	}
+
+	userUsecase := usecase.NewUserUsecase(userRepo, relationshipRepo, morningCallRepo, seriesRepo, outboxRepo, auditRepo, txManager, clock.System())
+	authUsecase := usecase.NewAuthUsecase(userRepo, sessionRepo, sessionTTL)
+	morningCallUsecase := usecase.NewMorningCallUsecase(morningCallRepo, seriesRepo, userRepo, relationshipRepo, outboxRepo, auditRepo, txManager, clock.System())
+	seriesUsecase := usecase.NewMorningCallSeriesUsecase(seriesRepo, morningCallRepo, userRepo, relationshipRepo, outboxRepo, txManager, clock.System(), seriesHorizon)
+	auditUsecase := usecase.NewAuditUsecase(auditRepo)
+	deliverer := usecase.NewNotificationDeliverer(userRepo, slog.Default(), newNotifiers()...)
+	dispatchUsecase := usecase.NewDispatchUsecase(morningCallRepo, outboxRepo, auditRepo, txManager, deliverer, clock.System(), ackWindow)
+
+	// ドメインイベントの購読者はここで登録する
+	eventRelay := usecase.NewEventRelay(outboxRepo, txManager)
+	eventRelay.Subscribe("log", 0, func(ctx context.Context, record *domain.EventRecord) error {
+		slog.InfoContext(ctx, "domain event", "offset", record.Offset, "type", record.Type, "data", string(record.Data))
+		return nil
+	})
+
 	userHandler := handler.NewUserHandler(userUsecase)
+	authHandler := handler.NewAuthHandler(authUsecase)
+	friendHandler := handler.NewFriendHandler(userUsecase)
+	morningCallHandler := handler.NewMorningCallHandler(morningCallUsecase)
+	seriesHandler := handler.NewMorningCallSeriesHandler(seriesUsecase)
+	auditHandler := handler.NewAuditHandler(auditUsecase)
+
+	authMiddleware := handler.NewAuthMiddleware(authUsecase, adminUserIDs()...)
+	requireAuth := authMiddleware.RequireAuth
+	requireAdmin := authMiddleware.RequireAdmin
 
 	http.HandleFunc("/users", userHandler.Register)
+	http.HandleFunc("POST /sessions", authHandler.Login)
+	http.HandleFunc("DELETE /sessions/current", requireAuth(authHandler.Logout))
+
+	http.HandleFunc("GET /me", requireAuth(userHandler.Me))
+	http.HandleFunc("GET /notification-settings", requireAuth(userHandler.GetNotificationSettings))
+	http.HandleFunc("PUT /notification-settings", requireAuth(userHandler.UpdateNotificationSettings))
+	http.HandleFunc("PUT /time-zone", requireAuth(userHandler.UpdateTimeZone))
+	http.HandleFunc("GET /preferences", requireAuth(userHandler.GetReceiverPreferences))
+	http.HandleFunc("PUT /preferences", requireAuth(userHandler.UpdateReceiverPreferences))
+
+	http.HandleFunc("POST /friends", requireAuth(friendHandler.Apply))
+	http.HandleFunc("GET /friends", requireAuth(friendHandler.List))
+	http.HandleFunc("GET /friend-requests", requireAuth(friendHandler.ListRequests))
+	http.HandleFunc("POST /friend-requests/{fromUserID}/approve", requireAuth(friendHandler.Approve))
+	http.HandleFunc("POST /friend-requests/{fromUserID}/reject", requireAuth(friendHandler.Reject))
+	http.HandleFunc("POST /friend-requests/{toUserID}/cancel", requireAuth(friendHandler.Cancel))
+	http.HandleFunc("DELETE /friends/{friendID}", requireAuth(friendHandler.Unfriend))
+	http.HandleFunc("POST /blocks", requireAuth(friendHandler.Block))
+	http.HandleFunc("GET /blocks", requireAuth(friendHandler.ListBlocked))
+	http.HandleFunc("DELETE /blocks/{userID}", requireAuth(friendHandler.Unblock))
+
+	http.HandleFunc("POST /friends/{friendID}/morning-calls", requireAuth(morningCallHandler.Create))
+	http.HandleFunc("GET /friends/{friendID}/morning-calls/{morningCallID}", requireAuth(morningCallHandler.Get))
+	http.HandleFunc("GET /morning-calls", requireAuth(morningCallHandler.List))
+	http.HandleFunc("PUT /morning-calls/{morningCallID}", requireAuth(morningCallHandler.Update))
+	http.HandleFunc("DELETE /morning-calls/{morningCallID}", requireAuth(morningCallHandler.Delete))
+	http.HandleFunc("POST /morning-calls/{morningCallID}/restore", requireAuth(morningCallHandler.Restore))
+	http.HandleFunc("POST /morning-calls/{morningCallID}/acknowledge", requireAuth(morningCallHandler.Acknowledge))
+	http.HandleFunc("POST /morning-calls/{morningCallID}/snooze", requireAuth(morningCallHandler.Snooze))
+
+	http.HandleFunc("POST /friends/{friendID}/morning-call-series", requireAuth(seriesHandler.Create))
+	http.HandleFunc("GET /morning-call-series", requireAuth(seriesHandler.List))
+	http.HandleFunc("GET /morning-call-series/{seriesID}", requireAuth(seriesHandler.Get))
+	http.HandleFunc("GET /morning-call-series/{seriesID}/occurrences", requireAuth(seriesHandler.Occurrences))
+	http.HandleFunc("POST /morning-call-series/{seriesID}/exceptions", requireAuth(seriesHandler.Skip))
+	http.HandleFunc("PUT /morning-call-series/{seriesID}/following", requireAuth(seriesHandler.UpdateFollowing))
+	http.HandleFunc("POST /morning-call-series/{seriesID}/cancel", requireAuth(seriesHandler.Cancel))
+
+	http.HandleFunc("GET /admin/audit-log", requireAdmin(auditHandler.List))
+
+	var workers sync.WaitGroup
+	runWorker := func(name string, interval time.Duration, job worker.Job) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			worker.Run(ctx, name, interval, job)
+		}()
+	}
+	runWorker("series-materializer", materializeInterval, seriesUsecase.MaterializeDue)
+	runWorker("dispatcher", dispatchInterval, dispatchUsecase.DispatchDue)
+	runWorker("ack-expirer", dispatchInterval, dispatchUsecase.ExpireUnacknowledged)
+	runWorker("event-relay", relayInterval, eventRelay.RelayPending)
+	runWorker("deleted-purger", purgeInterval, func(ctx context.Context) error {
+		return morningCallUsecase.PurgeDeleted(ctx, purgeRetention)
+	})
+	requestTTL := friendRequestTTL()
+	runWorker("friend-request-expirer", friendRequestExpireInterval, func(ctx context.Context) error {
+		return userUsecase.ExpireFriendRequests(ctx, requestTTL)
+	})
+
+	server := &http.Server{Addr: ":8080"}
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("could not shutdown server %v", err)
+		}
+	}()
 
 	log.Println("Server started on :8080")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("could not listen on port 8080 %v", err)
 	}
+
+	// 処理中のリクエストとワーカーの終了を待ってから保存先を閉じる
+	<-shutdownDone
+	workers.Wait()
+	if err := repos.close(); err != nil {
+		log.Printf("could not close repositories %v", err)
+	}
+}
+
+// repositories は保存先の設定に応じて生成したリポジトリです
+type repositories struct {
+	user         repository.UserRepository
+	session      repository.SessionRepository
+	morningCall  repository.MorningCallRepository
+	relationship repository.RelationshipRepository
+	series       repository.MorningCallSeriesRepository
+	outbox       repository.OutboxRepository
+	audit        repository.AuditRepository
+	tx           repository.TxManager
+	close        func() error
+}
+
+// newRepositories は環境変数の設定に応じてリポジトリを生成します
+//
+//	STORAGE   memory (既定値) または file
+//	DATA_DIR  file の場合のデータディレクトリ。既定値 data
+func newRepositories() (*repositories, error) {
+	switch storage := getenv("STORAGE", "memory"); storage {
+	case "memory":
+		return &repositories{
+			user:         inmemory.NewInMemoryUserRepository(),
+			session:      inmemory.NewInMemorySessionRepository(),
+			morningCall:  inmemory.NewInMemoryMorningCallRepository(),
+			relationship: inmemory.NewInMemoryRelationshipRepository(),
+			series:       inmemory.NewInMemoryMorningCallSeriesRepository(),
+			outbox:       inmemory.NewInMemoryOutboxRepository(),
+			audit:        inmemory.NewInMemoryAuditRepository(),
+			tx:           inmemory.NewInMemoryTxManager(),
+			close:        func() error { return nil },
+		}, nil
+
+	case "file":
+		dir := getenv("DATA_DIR", "data")
+		store, err := filestore.Open(dir, filestore.Options{Logger: slog.Default()})
+		if err != nil {
+			return nil, err
+		}
+		repos := &repositories{tx: filestore.NewFileTxManager(store), close: store.Close}
+		if repos.user, err = filestore.NewFileUserRepository(store); err != nil {
+			return nil, err
+		}
+		if repos.session, err = filestore.NewFileSessionRepository(store); err != nil {
+			return nil, err
+		}
+		if repos.morningCall, err = filestore.NewFileMorningCallRepository(store); err != nil {
+			return nil, err
+		}
+		if repos.relationship, err = filestore.NewFileRelationshipRepository(store); err != nil {
+			return nil, err
+		}
+		if repos.series, err = filestore.NewFileMorningCallSeriesRepository(store); err != nil {
+			return nil, err
+		}
+		if repos.outbox, err = filestore.NewFileOutboxRepository(store); err != nil {
+			return nil, err
+		}
+		if repos.audit, err = filestore.NewFileAuditRepository(store); err != nil {
+			return nil, err
+		}
+		log.Printf("Using file storage in %s", dir)
+		return repos, nil
+
+	default:
+		return nil, fmt.Errorf("unknown STORAGE %q", storage)
+	}
+}
+
+// adminUserIDs は管理者用エンドポイントを利用できるユーザーを環境変数から読み込みます
+//
+//	ADMIN_USER_IDS  カンマ区切りのユーザーID (未設定の場合は管理者なし)
+func adminUserIDs() []domain.UserID {
+	var ids []domain.UserID
+	for _, id := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, domain.UserID(id))
+		}
+	}
+	return ids
+}
+
+// friendRequestTTL はフレンド申請の有効期限を環境変数から読み込みます
+//
+//	FRIEND_REQUEST_TTL  time.ParseDuration 形式 (例: 168h)。既定値 720h
+func friendRequestTTL() time.Duration {
+	ttl, err := time.ParseDuration(getenv("FRIEND_REQUEST_TTL", defaultFriendRequestTTL.String()))
+	if err != nil || ttl <= 0 {
+		log.Fatalf("invalid FRIEND_REQUEST_TTL %q", os.Getenv("FRIEND_REQUEST_TTL"))
+	}
+	return ttl
+}
+
+// newNotifiers は環境変数の設定に応じて利用可能な通知チャネルを生成します
+//
+//	WEBHOOK_SECRET  Webhookリクエストの署名に使う秘密鍵 (任意)
+//	SMTP_HOST       設定した場合のみメール通知を有効にする
+//	SMTP_PORT       既定値 587
+//	SMTP_FROM       送信元アドレス
+//	SMTP_USERNAME   SMTP認証のユーザー名 (任意)
+//	SMTP_PASSWORD   SMTP認証のパスワード (任意)
+func newNotifiers() []usecase.Notifier {
+	notifiers := []usecase.Notifier{
+		notifier.NewLogNotifier(slog.Default()),
+		notifier.NewWebhookNotifier(notifyTimeout, os.Getenv("WEBHOOK_SECRET")),
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port, err := strconv.Atoi(getenv("SMTP_PORT", "587"))
+		if err != nil {
+			log.Fatalf("invalid SMTP_PORT %v", err)
+		}
+		notifiers = append(notifiers, notifier.NewSMTPNotifier(notifier.SMTPConfig{
+			Host:     host,
+			Port:     port,
+			From:     os.Getenv("SMTP_FROM"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+		}))
+	}
+
+	return notifiers
+}
+
+// getenv は環境変数を取得し、未設定の場合は既定値を返します
+func getenv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
 }
@@ -2,4 +2,4 @@ module morning-call
 
 go 1.24.3
 
-require github.com/google/uuid v1.6.0 // indirect
+require github.com/google/uuid v1.6.0
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// AuditAction は監査ログに記録する操作の種類です
+type AuditAction string
+
+const (
+	AuditActionUserRegistered              AuditAction = "user.registered"
+	AuditActionNotificationSettingsUpdated AuditAction = "user.notification_settings_updated"
+	AuditActionReceiverPreferencesUpdated  AuditAction = "user.receiver_preferences_updated"
+	AuditActionTimeZoneUpdated             AuditAction = "user.time_zone_updated"
+	AuditActionFriendRequested             AuditAction = "friend.requested"
+	AuditActionFriendApproved              AuditAction = "friend.approved"
+	AuditActionFriendRejected              AuditAction = "friend.rejected"
+	AuditActionFriendRequestCancelled      AuditAction = "friend.request_cancelled"
+	AuditActionFriendRequestExpired        AuditAction = "friend.request_expired"
+	AuditActionUnfriended                  AuditAction = "friend.unfriended"
+	AuditActionUserBlocked                 AuditAction = "friend.blocked"
+	AuditActionUserUnblocked               AuditAction = "friend.unblocked"
+	AuditActionMorningCallCreated          AuditAction = "morning_call.created"
+	AuditActionMorningCallUpdated          AuditAction = "morning_call.updated"
+	AuditActionMorningCallDeleted          AuditAction = "morning_call.deleted"
+	AuditActionMorningCallRestored         AuditAction = "morning_call.restored"
+	AuditActionMorningCallAcknowledged     AuditAction = "morning_call.acknowledged"
+	AuditActionMorningCallSnoozed          AuditAction = "morning_call.snoozed"
+	AuditActionMorningCallPurged           AuditAction = "morning_call.purged"
+	AuditActionMorningCallExpired          AuditAction = "morning_call.expired"
+	AuditActionMorningCallSeriesCancelled  AuditAction = "morning_call_series.cancelled"
+)
+
+// AuditEntityType は操作対象のエンティティの種類です
+type AuditEntityType string
+
+const (
+	AuditEntityUser         AuditEntityType = "user"
+	AuditEntityRelationship AuditEntityType = "relationship"
+	AuditEntityMorningCall  AuditEntityType = "morning_call"
+	AuditEntitySeries       AuditEntityType = "morning_call_series"
+)
+
+// SystemActor はバックグラウンド処理による操作の実行者です
+const SystemActor UserID = "system"
+
+// AuditEntry は監査ログの1件です。追記のみで、保存後に変更・削除はしません
+type AuditEntry struct {
+	Seq           uint64 // 監査ログ内の通し番号。リポジトリが採番する
+	ActorID       UserID // 操作したユーザー。バックグラウンド処理は SystemActor
+	Action        AuditAction
+	EntityType    AuditEntityType
+	EntityID      string
+	TargetUserIDs []UserID // 操作の影響を受けるユーザー（モーニングコールの送信者と受信者など）
+	BeforeStatus  string
+	AfterStatus   string
+	OccurredAt    time.Time
+}
+
+// Involves はユーザーが操作者または相手としてこのエントリに関わっているかを返します
+func (rcv *AuditEntry) Involves(userID UserID) bool {
+	return rcv.ActorID == userID || slices.Contains(rcv.TargetUserIDs, userID)
+}
@@ -1,6 +1,7 @@
 package domain
 
 type (
-	UserID        string
-	MorningCallID string
+	UserID              string
+	MorningCallID       string
+	MorningCallSeriesID string
 )
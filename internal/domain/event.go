@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType はドメインイベントの種類です
+type EventType string
+
+const (
+	EventTypeFriendRequestSent       EventType = "friend_request.sent"
+	EventTypeFriendRequestApproved   EventType = "friend_request.approved"
+	EventTypeMorningCallScheduled    EventType = "morning_call.scheduled"
+	EventTypeMorningCallFired        EventType = "morning_call.fired"
+	EventTypeMorningCallAcknowledged EventType = "morning_call.acknowledged"
+)
+
+// Event はユースケースが状態の変更とともに発行するドメインイベントです
+type Event interface {
+	EventType() EventType
+}
+
+// FriendRequestSent はフレンド申請が送られたことを表します
+type FriendRequestSent struct {
+	RelationshipID RelationshipID `json:"relationship_id"`
+	RequesterID    UserID         `json:"requester_id"`
+	ReceiverID     UserID         `json:"receiver_id"`
+}
+
+func (FriendRequestSent) EventType() EventType { return EventTypeFriendRequestSent }
+
+// FriendRequestApproved はフレンド申請が承認されたことを表します
+type FriendRequestApproved struct {
+	RelationshipID RelationshipID `json:"relationship_id"`
+	RequesterID    UserID         `json:"requester_id"`
+	ReceiverID     UserID         `json:"receiver_id"`
+}
+
+func (FriendRequestApproved) EventType() EventType { return EventTypeFriendRequestApproved }
+
+// MorningCallScheduled はモーニングコールが設定されたことを表します
+type MorningCallScheduled struct {
+	MorningCallID MorningCallID       `json:"morning_call_id"`
+	SenderID      UserID              `json:"sender_id"`
+	ReceiverID    UserID              `json:"receiver_id"`
+	Time          time.Time           `json:"time"`
+	SeriesID      MorningCallSeriesID `json:"series_id,omitempty"`
+}
+
+func (MorningCallScheduled) EventType() EventType { return EventTypeMorningCallScheduled }
+
+// MorningCallFired はモーニングコールが受信者に配信されたことを表します
+type MorningCallFired struct {
+	MorningCallID MorningCallID `json:"morning_call_id"`
+	SenderID      UserID        `json:"sender_id"`
+	ReceiverID    UserID        `json:"receiver_id"`
+	DeliveredAt   time.Time     `json:"delivered_at"`
+	AckDeadline   time.Time     `json:"ack_deadline"`
+}
+
+func (MorningCallFired) EventType() EventType { return EventTypeMorningCallFired }
+
+// MorningCallAcknowledged は受信者がモーニングコールに応答したことを表します
+type MorningCallAcknowledged struct {
+	MorningCallID  MorningCallID `json:"morning_call_id"`
+	SenderID       UserID        `json:"sender_id"`
+	ReceiverID     UserID        `json:"receiver_id"`
+	AcknowledgedAt time.Time     `json:"acknowledged_at"`
+}
+
+func (MorningCallAcknowledged) EventType() EventType { return EventTypeMorningCallAcknowledged }
+
+// EventRecord はアウトボックスに保存したドメインイベントです
+// Offset はアウトボックス内の通し番号で、リポジトリが1から順に採番します
+type EventRecord struct {
+	Offset     uint64
+	Type       EventType
+	OccurredAt time.Time
+	Data       json.RawMessage
+}
+
+// NewEventRecord はイベントを保存用のレコードに変換します
+func NewEventRecord(event Event, occurredAt time.Time) (*EventRecord, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+	return &EventRecord{
+		Type:       event.EventType(),
+		OccurredAt: occurredAt,
+		Data:       data,
+	}, nil
+}
+
+// Event はレコードを型付きのイベントに復元します
+func (rcv *EventRecord) Event() (Event, error) {
+	var event Event
+	switch rcv.Type {
+	case EventTypeFriendRequestSent:
+		event = &FriendRequestSent{}
+	case EventTypeFriendRequestApproved:
+		event = &FriendRequestApproved{}
+	case EventTypeMorningCallScheduled:
+		event = &MorningCallScheduled{}
+	case EventTypeMorningCallFired:
+		event = &MorningCallFired{}
+	case EventTypeMorningCallAcknowledged:
+		event = &MorningCallAcknowledged{}
+	default:
+		return nil, fmt.Errorf("unknown event type %q", rcv.Type)
+	}
+
+	if err := json.Unmarshal(rcv.Data, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", rcv.Type, err)
+	}
+	return event, nil
+}
@@ -12,4 +12,42 @@ type MorningCall struct {
 	Time       time.Time
 	Message    string
 	Status     MorningCallStatus
+	SeriesID   MorningCallSeriesID // 繰り返し設定から生成された場合の生成元
+
+	DeliveredAt    time.Time // 配信した時刻
+	AckDeadline    time.Time // この時刻までに応答がなければ失敗とする
+	AcknowledgedAt time.Time // 受信者が応答した時刻
+
+	SnoozeCount  int       // スヌーズした回数
+	SnoozedUntil time.Time // スヌーズ後に再配信する時刻
+
+	DeletedAt time.Time // 削除した時刻。RestoreWindow の間は復元できる
+
+	Version int // 楽観的排他制御のバージョン。更新のたびにリポジトリが加算する
+}
+
+// スヌーズできる最大回数
+const MaxSnoozeCount = 3
+
+// 削除したモーニングコールを復元できる期間
+const RestoreWindow = 24 * time.Hour
+
+// FireTime returns the time the morning call should be delivered next
+func (rcv *MorningCall) FireTime() time.Time {
+	if rcv.Status == MorningCallStatusSnoozed {
+		return rcv.SnoozedUntil
+	}
+	return rcv.Time
+}
+
+// SoftDelete marks the morning call as deleted, keeping it restorable for RestoreWindow
+func (rcv *MorningCall) SoftDelete(now time.Time) {
+	rcv.Status = MorningCallStatusDeleted
+	rcv.DeletedAt = now
+}
+
+// Restore returns a deleted morning call to the schedule
+func (rcv *MorningCall) Restore() {
+	rcv.Status = MorningCallStatusScheduled
+	rcv.DeletedAt = time.Time{}
 }
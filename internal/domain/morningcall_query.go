@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// MorningCallDirection はユーザーから見たモーニングコールの向きです
+type MorningCallDirection string
+
+const (
+	MorningCallDirectionAll      MorningCallDirection = ""         // 送信・受信の両方
+	MorningCallDirectionSent     MorningCallDirection = "sent"     // ユーザーが送信したもの
+	MorningCallDirectionReceived MorningCallDirection = "received" // ユーザーが受信したもの
+)
+
+// IsValid は定義済みの向きかどうかを返します
+func (rcv MorningCallDirection) IsValid() bool {
+	switch rcv {
+	case MorningCallDirectionAll, MorningCallDirectionSent, MorningCallDirectionReceived:
+		return true
+	}
+	return false
+}
+
+// MorningCallCursor はページングの位置です。並び順のキー (Time, ID) を保持します
+type MorningCallCursor struct {
+	Time time.Time
+	ID   MorningCallID
+}
+
+// MorningCallQuery はユーザーのモーニングコール一覧の検索条件です
+// 結果は Time の順（同時刻は ID の順）に並べ、After より後ろから最大 Limit 件を返します
+type MorningCallQuery struct {
+	UserID    UserID
+	Direction MorningCallDirection
+	Statuses  []MorningCallStatus // 空の場合は削除済みを除く全ステータス
+	From      time.Time           // Time がこの時刻以降のもの（ゼロ値は制限なし）
+	To        time.Time           // Time がこの時刻より前のもの（ゼロ値は制限なし）
+	FriendID  UserID              // 相手ユーザー（空の場合は全員）
+
+	Descending bool               // true の場合は新しい順
+	After      *MorningCallCursor // 前のページの最後の位置（nil の場合は先頭から）
+	Limit      int                // 0 の場合は全件
+}
+
+// Matches はモーニングコールが検索条件（ページングを除く）に一致するかを返します
+func (rcv *MorningCallQuery) Matches(mc *MorningCall) bool {
+	var counterpart UserID
+	switch {
+	case mc.SenderID == rcv.UserID && rcv.Direction != MorningCallDirectionReceived:
+		counterpart = mc.ReceiverID
+	case mc.ReceiverID == rcv.UserID && rcv.Direction != MorningCallDirectionSent:
+		counterpart = mc.SenderID
+	default:
+		return false
+	}
+
+	if rcv.FriendID != "" && counterpart != rcv.FriendID {
+		return false
+	}
+	if len(rcv.Statuses) == 0 {
+		// 削除済みは明示的に指定した場合のみ含める
+		if mc.Status == MorningCallStatusDeleted {
+			return false
+		}
+	} else if !slices.Contains(rcv.Statuses, mc.Status) {
+		return false
+	}
+	if !rcv.From.IsZero() && mc.Time.Before(rcv.From) {
+		return false
+	}
+	if !rcv.To.IsZero() && !mc.Time.Before(rcv.To) {
+		return false
+	}
+	return true
+}
+
+// Less は並び順で a が b より前かどうかを返します。同じ位置の場合は false です
+func (rcv *MorningCallQuery) Less(a, b MorningCallCursor) bool {
+	if rcv.Descending {
+		a, b = b, a
+	}
+	if !a.Time.Equal(b.Time) {
+		return a.Time.Before(b.Time)
+	}
+	return a.ID < b.ID
+}
+
+// CursorOf はモーニングコールの並び順の位置を返します
+func CursorOf(mc *MorningCall) MorningCallCursor {
+	return MorningCallCursor{Time: mc.Time, ID: mc.ID}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMorningCallQuery_Less(t *testing.T) {
+	at := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	early := MorningCallCursor{Time: at, ID: "b"}
+	late := MorningCallCursor{Time: at.Add(time.Minute), ID: "a"}
+	sameTime := MorningCallCursor{Time: at, ID: "c"}
+
+	tests := []struct {
+		name       string
+		a, b       MorningCallCursor
+		descending bool
+		want       bool
+	}{
+		{name: "earlier time", a: early, b: late, want: true},
+		{name: "later time", a: late, b: early, want: false},
+		{name: "same time orders by id", a: early, b: sameTime, want: true},
+		{name: "same position", a: early, b: early, want: false},
+		{name: "descending earlier time", a: early, b: late, descending: true, want: false},
+		{name: "descending later time", a: late, b: early, descending: true, want: true},
+		{name: "descending same time orders by id", a: sameTime, b: early, descending: true, want: true},
+		// 同じ位置を前と判定すると、カーソルの位置のものが次のページにも含まれる
+		{name: "descending same position", a: early, b: early, descending: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := MorningCallQuery{Descending: tt.descending}
+			if got := query.Less(tt.a, tt.b); got != tt.want {
+				t.Errorf("Less(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type MorningCallSeriesStatus string
+
+const (
+	MorningCallSeriesStatusActive    MorningCallSeriesStatus = "active"
+	MorningCallSeriesStatusCancelled MorningCallSeriesStatus = "cancelled"
+)
+
+// 繰り返しモーニングコールの設定
+// Start は初回の日時で、以降の発生日時は Rule に従って TimeZone における Start の時刻に展開される
+type MorningCallSeries struct {
+	ID         MorningCallSeriesID
+	SenderID   UserID
+	ReceiverID UserID
+	Start      time.Time
+	// TimeZone は展開に使う IANA タイムゾーン名。受信者のタイムゾーンを設定する
+	TimeZone string
+	Rule     RecurrenceRule
+	Message  string
+	Status   MorningCallSeriesStatus
+	// Exceptions はスキップする発生日時
+	Exceptions []time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// LocalStart returns Start in the series time zone, which anchors the wall-clock time of every occurrence
+func (rcv *MorningCallSeries) LocalStart() time.Time {
+	return rcv.Start.In(LoadLocation(rcv.TimeZone))
+}
+
+// Occurrences returns the occurrences within [from, to] excluding skipped ones
+func (rcv *MorningCallSeries) Occurrences(from, to time.Time) []time.Time {
+	if rcv.Status != MorningCallSeriesStatusActive {
+		return nil
+	}
+
+	var result []time.Time
+	for _, t := range rcv.Rule.Occurrences(rcv.LocalStart(), from, to) {
+		if !rcv.IsSkipped(t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// IsOccurrence checks if t is generated by the series rule
+func (rcv *MorningCallSeries) IsOccurrence(t time.Time) bool {
+	found := false
+	rcv.Rule.Expand(rcv.LocalStart(), t, func(occ time.Time) bool {
+		if occ.Equal(t) {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// IsSkipped checks if the occurrence at t has been skipped
+func (rcv *MorningCallSeries) IsSkipped(t time.Time) bool {
+	for _, ex := range rcv.Exceptions {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanUpdate checks if the series can be changed by the user
+func (rcv *MorningCallSeries) CanUpdate(userID UserID) NGReason {
+	// 送信者のみが更新可能
+	if rcv.SenderID != userID {
+		return NGReasonNotSender
+	}
+
+	if rcv.Status != MorningCallSeriesStatusActive {
+		return NGReasonSeriesCancelled
+	}
+	return ""
+}
+
+// CanChangeFrom checks if the user can skip, edit or cancel the series from the given occurrence
+func (rcv *MorningCallSeries) CanChangeFrom(userID UserID, occurrence time.Time) NGReason {
+	if ng := rcv.CanUpdate(userID); ng.IsNG() {
+		return ng
+	}
+
+	if !rcv.IsOccurrence(occurrence) || rcv.IsSkipped(occurrence) {
+		return NGReasonNotOccurrence
+	}
+	return ""
+}
+
+// Skip excludes a single occurrence
+func (rcv *MorningCallSeries) Skip(occurrence time.Time, now time.Time) {
+	rcv.Exceptions = append(rcv.Exceptions, occurrence)
+	rcv.UpdatedAt = now
+}
+
+// Unskip removes the occurrence from the exceptions, e.g. when its deleted morning call is restored
+func (rcv *MorningCallSeries) Unskip(occurrence time.Time, now time.Time) {
+	rcv.Exceptions = slices.DeleteFunc(rcv.Exceptions, occurrence.Equal)
+	rcv.UpdatedAt = now
+}
+
+// Cancel ends the whole series
+func (rcv *MorningCallSeries) Cancel(now time.Time) {
+	rcv.Status = MorningCallSeriesStatusCancelled
+	rcv.UpdatedAt = now
+}
+
+// RemainingRule returns the rule that continues the series from the given occurrence.
+// A COUNT rule is reduced by the occurrences before it, including skipped ones.
+func (rcv *MorningCallSeries) RemainingRule(occurrence time.Time) RecurrenceRule {
+	rule := rcv.Rule
+	if rule.Count == 0 {
+		return rule
+	}
+
+	before := 0
+	rule.Expand(rcv.LocalStart(), occurrence, func(t time.Time) bool {
+		if t.Before(occurrence) {
+			before++
+		}
+		return true
+	})
+	rule.Count -= before
+	return rule
+}
+
+// EndBefore ends the series just before the given occurrence ("this and following").
+// Ending at the first occurrence cancels the whole series.
+func (rcv *MorningCallSeries) EndBefore(occurrence time.Time, now time.Time) {
+	if !occurrence.After(rcv.Start) {
+		rcv.Cancel(now)
+		return
+	}
+
+	rcv.UpdatedAt = now
+
+	// COUNT で終わる設定も、指定日時より前で打ち切るため UNTIL に置き換える
+	rcv.Rule.Count = 0
+	rcv.Rule.Until = occurrence.Add(-time.Second)
+}
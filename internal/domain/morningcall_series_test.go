@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func newTestSeries(t *testing.T, start time.Time, timeZone, rule string) *MorningCallSeries {
+	t.Helper()
+
+	parsed, err := ParseRecurrenceRule(rule)
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule(%q): %v", rule, err)
+	}
+	return &MorningCallSeries{
+		ID:         "series-1",
+		SenderID:   "sender",
+		ReceiverID: "receiver",
+		Start:      start,
+		TimeZone:   timeZone,
+		Rule:       parsed,
+		Status:     MorningCallSeriesStatusActive,
+	}
+}
+
+func TestMorningCallSeries_OccurrencesSkipsExceptions(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	series := newTestSeries(t, start, "UTC", "FREQ=DAILY;COUNT=4")
+	series.Skip(addDays(start, 1), start)
+
+	want := []time.Time{start, addDays(start, 2), addDays(start, 3)}
+	if got := series.Occurrences(start, addDays(start, 10)); !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("Occurrences = %v, want %v", got, want)
+	}
+
+	series.Unskip(addDays(start, 1), start)
+	if got := series.Occurrences(start, addDays(start, 10)); len(got) != 4 {
+		t.Errorf("Occurrences after Unskip = %v, want 4", got)
+	}
+
+	series.Cancel(start)
+	if got := series.Occurrences(start, addDays(start, 10)); got != nil {
+		t.Errorf("Occurrences of a cancelled series = %v, want none", got)
+	}
+}
+
+func TestMorningCallSeries_CanChangeFrom(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	series := newTestSeries(t, start, "UTC", "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4")
+	series.Skip(addDays(start, 2), start)
+
+	tests := []struct {
+		name       string
+		userID     UserID
+		occurrence time.Time
+		want       NGReason
+	}{
+		{"first occurrence", "sender", start, ""},
+		{"last occurrence", "sender", addDays(start, 9), ""},
+		{"receiver", "receiver", start, NGReasonNotSender},
+		{"skipped", "sender", addDays(start, 2), NGReasonNotOccurrence},
+		{"other weekday", "sender", addDays(start, 1), NGReasonNotOccurrence},
+		{"other time", "sender", start.Add(time.Minute), NGReasonNotOccurrence},
+		{"after COUNT", "sender", addDays(start, 14), NGReasonNotOccurrence},
+		{"before start", "sender", addDays(start, -7), NGReasonNotOccurrence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := series.CanChangeFrom(tt.userID, tt.occurrence); got != tt.want {
+				t.Errorf("CanChangeFrom = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	series.Cancel(start)
+	if got := series.CanChangeFrom("sender", start); got != NGReasonSeriesCancelled {
+		t.Errorf("CanChangeFrom on a cancelled series = %q, want %q", got, NGReasonSeriesCancelled)
+	}
+}
+
+func TestMorningCallSeries_EndBefore(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		rule       string
+		occurrence time.Time
+		want       []time.Time
+		cancelled  bool
+	}{
+		{"open ended", "FREQ=DAILY", addDays(start, 2), []time.Time{start, addDays(start, 1)}, false},
+		{"count", "FREQ=DAILY;COUNT=5", addDays(start, 3), []time.Time{start, addDays(start, 1), addDays(start, 2)}, false},
+		{"until", "FREQ=DAILY;UNTIL=20261030", addDays(start, 1), []time.Time{start}, false},
+		{"second", "FREQ=WEEKLY;BYDAY=MO,FR", addDays(start, 4), []time.Time{start}, false},
+		{"first", "FREQ=DAILY;COUNT=5", start, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := newTestSeries(t, start, "UTC", tt.rule)
+			series.EndBefore(tt.occurrence, now)
+
+			if cancelled := series.Status == MorningCallSeriesStatusCancelled; cancelled != tt.cancelled {
+				t.Fatalf("cancelled = %v, want %v", cancelled, tt.cancelled)
+			}
+			if got := series.Occurrences(start, addDays(start, 30)); !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("Occurrences after EndBefore = %v, want %v", got, tt.want)
+			}
+			if !tt.cancelled && series.Rule.Count != 0 {
+				t.Errorf("Count = %d, want it replaced by UNTIL", series.Rule.Count)
+			}
+			if !series.UpdatedAt.Equal(now) {
+				t.Errorf("UpdatedAt = %v, want %v", series.UpdatedAt, now)
+			}
+		})
+	}
+}
+
+func TestMorningCallSeries_RemainingRule(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		rule       string
+		skip       []time.Time
+		occurrence time.Time
+		wantCount  int
+	}{
+		{"without count", "FREQ=DAILY", nil, addDays(start, 3), 0},
+		{"until", "FREQ=DAILY;UNTIL=20261030", nil, addDays(start, 3), 0},
+		{"from first", "FREQ=DAILY;COUNT=5", nil, start, 5},
+		{"from middle", "FREQ=DAILY;COUNT=5", nil, addDays(start, 3), 2},
+		{"from last", "FREQ=DAILY;COUNT=5", nil, addDays(start, 4), 1},
+		// スキップした発生日時も COUNT を消化している
+		{"after skipped", "FREQ=DAILY;COUNT=5", []time.Time{addDays(start, 1)}, addDays(start, 3), 2},
+		{"weekly", "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=6", nil, addDays(start, 7), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := newTestSeries(t, start, "UTC", tt.rule)
+			for _, s := range tt.skip {
+				series.Skip(s, start)
+			}
+
+			got := series.RemainingRule(tt.occurrence)
+			if got.Count != tt.wantCount {
+				t.Errorf("Count = %d, want %d", got.Count, tt.wantCount)
+			}
+			if !got.Until.Equal(series.Rule.Until) || got.Freq != series.Rule.Freq {
+				t.Errorf("RemainingRule = %+v, want the rest of %+v unchanged", got, series.Rule)
+			}
+		})
+	}
+}
+
+// 「この日以降」で分割した前後の設定を合わせると、元の設定と同じ発生日時になる
+func TestMorningCallSeries_SplitKeepsOccurrences(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	to := addDays(start, 60)
+
+	for _, rule := range []string{"FREQ=DAILY;COUNT=10", "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=7", "FREQ=DAILY;INTERVAL=3;UNTIL=20261120"} {
+		t.Run(rule, func(t *testing.T) {
+			original := newTestSeries(t, start, "UTC", rule)
+			all := original.Occurrences(start, to)
+
+			for _, occurrence := range all[1:] {
+				head := newTestSeries(t, start, "UTC", rule)
+				following := newTestSeries(t, occurrence, "UTC", rule)
+				following.Rule = head.RemainingRule(occurrence)
+				head.EndBefore(occurrence, start)
+
+				got := append(head.Occurrences(start, to), following.Occurrences(start, to)...)
+				if !slices.EqualFunc(got, all, time.Time.Equal) {
+					t.Errorf("split at %v = %v, want %v", occurrence, got, all)
+				}
+			}
+		})
+	}
+}
+
+// 発生日時は夏時間の切り替えをまたいでも、設定のタイムゾーンで同じ現地時刻になる
+func TestMorningCallSeries_OccurrencesKeepWallClockAcrossDST(t *testing.T) {
+	tests := []struct {
+		name  string
+		zone  string
+		start time.Time // 初回の現地日付 (07:00)
+		rule  string
+		count int
+	}{
+		// 2026-11-01 に夏時間が終わる
+		{"new york fall back", "America/New_York", time.Date(2026, 10, 29, 7, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=6", 6},
+		// 2026-03-08 に夏時間が始まる
+		{"new york spring forward", "America/New_York", time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=6", 6},
+		{"new york weekdays", "America/New_York", time.Date(2026, 10, 26, 7, 0, 0, 0, time.UTC), "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=10", 10},
+		// 2026-10-25 に夏時間が終わる
+		{"london", "Europe/London", time.Date(2026, 10, 22, 7, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=6", 6},
+		// 南半球では 2026-10-04 に夏時間が始まる
+		{"sydney", "Australia/Sydney", time.Date(2026, 10, 1, 7, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=6", 6},
+		{"tokyo", "Asia/Tokyo", time.Date(2026, 10, 29, 7, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=6", 6},
+		{"utc", "UTC", time.Date(2026, 10, 29, 7, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=6", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := LoadLocation(tt.zone)
+			y, m, d := tt.start.Date()
+			localStart := time.Date(y, m, d, 7, 0, 0, 0, loc)
+
+			// Start は UTC で保存されていても、展開は TimeZone の現地時刻で行う
+			series := newTestSeries(t, localStart.UTC(), tt.zone, tt.rule)
+			if got := series.LocalStart(); got.Location().String() != tt.zone || got.Hour() != 7 {
+				t.Fatalf("LocalStart = %v, want 07:00 in %s", got, tt.zone)
+			}
+
+			got := series.Occurrences(localStart, localStart.AddDate(0, 0, 14))
+			if len(got) != tt.count {
+				t.Fatalf("Occurrences = %v, want %d", got, tt.count)
+			}
+
+			offsets := make(map[int]bool)
+			for i, occ := range got {
+				local := occ.In(loc)
+				if local.Hour() != 7 || local.Minute() != 0 {
+					t.Errorf("occurrence %d = %v, want 07:00 local", i, local)
+				}
+				if i > 0 && !local.After(got[i-1]) {
+					t.Errorf("occurrence %d = %v is not after %v", i, local, got[i-1])
+				}
+				_, offset := local.Zone()
+				offsets[offset] = true
+			}
+
+			// 夏時間のある地域は切り替えをまたいでいることを確認する
+			wantOffsets := 2
+			if tt.zone == "Asia/Tokyo" || tt.zone == "UTC" {
+				wantOffsets = 1
+			}
+			if len(offsets) != wantOffsets {
+				t.Errorf("occurrences span %d UTC offsets, want %d", len(offsets), wantOffsets)
+			}
+
+			// 切り替え前後の発生日時はすべて発生日時として判定できる
+			for _, occ := range got {
+				if !series.IsOccurrence(occ) {
+					t.Errorf("IsOccurrence(%v) = false", occ)
+				}
+			}
+		})
+	}
+}
@@ -3,8 +3,21 @@ package domain
 type MorningCallStatus string
 
 const (
-	MorningCallStatusScheduled MorningCallStatus = "scheduled"
-	MorningCallStatusDeleted   MorningCallStatus = "deleted"
-	MorningCallStatusCompleted MorningCallStatus = "completed"
-	MorningCallStatusFailed    MorningCallStatus = "failed"
+	MorningCallStatusScheduled    MorningCallStatus = "scheduled"
+	MorningCallStatusDeleted      MorningCallStatus = "deleted"
+	MorningCallStatusCompleted    MorningCallStatus = "completed"
+	MorningCallStatusFailed       MorningCallStatus = "failed"
+	MorningCallStatusDelivered    MorningCallStatus = "delivered"    // 配信済み・受信者の応答待ち
+	MorningCallStatusAcknowledged MorningCallStatus = "acknowledged" // 受信者が起床を応答済み
+	MorningCallStatusSnoozed      MorningCallStatus = "snoozed"      // 受信者がスヌーズ中・SnoozedUntil に再配信
 )
+
+// IsValid は定義済みのステータスかどうかを返します
+func (rcv MorningCallStatus) IsValid() bool {
+	switch rcv {
+	case MorningCallStatusScheduled, MorningCallStatusDeleted, MorningCallStatusCompleted, MorningCallStatusFailed,
+		MorningCallStatusDelivered, MorningCallStatusAcknowledged, MorningCallStatusSnoozed:
+		return true
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"morning-call/internal/shared/validation"
 )
 
 // IsSender checks if the specified user is the sender of this morning call
@@ -54,30 +56,74 @@ func (rcv *MorningCall) CanDelete(userID UserID) NGReason {
 	}
 }
 
-// ValidateScheduledTime validates if the scheduled time is valid
-func (rcv *MorningCall) ValidateScheduledTime() NGReason {
-	now := time.Now()
+// CanRestore checks if the deleted morning call can be restored at the given time
+func (rcv *MorningCall) CanRestore(userID UserID, now time.Time) NGReason {
+	// 削除できるユーザーが復元可能
+	if !rcv.IsSender(userID) && !rcv.IsReceiver(userID) {
+		return NGReasonNoPermission
+	}
+
+	if rcv.Status != MorningCallStatusDeleted {
+		return NGReasonNotDeleted
+	}
+	if now.After(rcv.DeletedAt.Add(RestoreWindow)) {
+		return NGReasonRestoreExpired
+	}
+
+	// 予定時刻を過ぎたものは復元しても配信できない
+	if !rcv.Time.After(now) {
+		return NGReasonPastTime
+	}
 
+	return ""
+}
+
+// ValidateScheduledTime validates if the scheduled time is valid at now
+func (rcv *MorningCall) ValidateScheduledTime(now time.Time) NGReason {
 	// 過去の時刻チェック
 	if rcv.Time.Before(now) {
 		return NGReasonPastTime
 	}
 
-	// 設定可能期間のチェック（例：30日以内）
-	maxFuture := now.AddDate(0, 0, 30)
-	if rcv.Time.After(maxFuture) {
+	// 設定可能期間のチェック（validation.MaxScheduleDaysAhead 日以内）
+	if rcv.Time.After(validation.ScheduleHorizon(now)) {
 		return NGReasonTooFarInFuture
 	}
 
 	return ""
 }
 
-// CanComplete checks if the morning call can be marked as complete
-func (rcv *MorningCall) CanComplete() NGReason {
+// ScheduleConflict checks t against the receiver's other morning calls
+// Calls at the same time are duplicates, and calls closer than minGap are too close.
+// Deleted and failed calls are ignored, as is the call being rescheduled (excludeID).
+func ScheduleConflict(t time.Time, minGap time.Duration, others []*MorningCall, excludeID MorningCallID) NGReason {
+	var tooClose bool
+	for _, mc := range others {
+		if mc.ID == excludeID || mc.Status == MorningCallStatusDeleted || mc.Status == MorningCallStatusFailed {
+			continue
+		}
+
+		gap := mc.Time.Sub(t).Abs()
+		if gap == 0 {
+			return NGReasonDuplicateSchedule
+		}
+		if gap < minGap {
+			tooClose = true
+		}
+	}
+
+	if tooClose {
+		return NGReasonScheduleTooClose
+	}
+	return ""
+}
+
+// CanComplete checks if the morning call can be marked as complete at the given time
+func (rcv *MorningCall) CanComplete(now time.Time) NGReason {
 	switch rcv.Status {
-	case MorningCallStatusScheduled:
-		// 予定時刻を過ぎているかチェック
-		if time.Now().Before(rcv.Time) {
+	case MorningCallStatusScheduled, MorningCallStatusSnoozed:
+		// 予定時刻（スヌーズ中は再配信時刻）を過ぎているかチェック
+		if now.Before(rcv.FireTime()) {
 			return NGReasonInvalidTime
 		}
 		return ""
@@ -89,3 +135,70 @@ func (rcv *MorningCall) CanComplete() NGReason {
 		return NGReasonInvalidStatus
 	}
 }
+
+// CanAcknowledge checks if the receiver can acknowledge the morning call at the given time
+func (rcv *MorningCall) CanAcknowledge(userID UserID, now time.Time) NGReason {
+	// 受信者のみが応答可能
+	if !rcv.IsReceiver(userID) {
+		return NGReasonNotReceiver
+	}
+
+	switch rcv.Status {
+	case MorningCallStatusDelivered:
+		if rcv.IsAckOverdue(now) {
+			return NGReasonAckDeadlinePassed
+		}
+		return ""
+	case MorningCallStatusSnoozed:
+		// スヌーズ中に起きた場合も応答できる
+		return ""
+	case MorningCallStatusScheduled:
+		return NGReasonNotDelivered
+	case MorningCallStatusAcknowledged:
+		return NGReasonAlreadyAcknowledged
+	case MorningCallStatusFailed:
+		return NGReasonAckDeadlinePassed
+	case MorningCallStatusDeleted:
+		return NGReasonAlreadyDeleted
+	default:
+		return NGReasonInvalidStatus
+	}
+}
+
+// IsAckOverdue checks if the delivered morning call was not acknowledged in time
+func (rcv *MorningCall) IsAckOverdue(now time.Time) bool {
+	return rcv.Status == MorningCallStatusDelivered && now.After(rcv.AckDeadline)
+}
+
+// CanSnooze checks if the receiver can snooze the ringing morning call at the given time
+func (rcv *MorningCall) CanSnooze(userID UserID, now time.Time) NGReason {
+	// 受信者のみがスヌーズ可能
+	if !rcv.IsReceiver(userID) {
+		return NGReasonNotReceiver
+	}
+
+	// ステータスチェック
+	switch rcv.Status {
+	case MorningCallStatusDelivered:
+		if rcv.IsAckOverdue(now) {
+			return NGReasonAckDeadlinePassed
+		}
+	case MorningCallStatusScheduled, MorningCallStatusSnoozed:
+		return NGReasonNotDelivered
+	case MorningCallStatusAcknowledged:
+		return NGReasonAlreadyAcknowledged
+	case MorningCallStatusFailed:
+		return NGReasonAckDeadlinePassed
+	case MorningCallStatusDeleted:
+		return NGReasonAlreadyDeleted
+	default:
+		return NGReasonInvalidStatus
+	}
+
+	// 回数制限
+	if rcv.SnoozeCount >= MaxSnoozeCount {
+		return NGReasonSnoozeLimitReached
+	}
+
+	return ""
+}
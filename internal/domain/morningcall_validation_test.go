@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMorningCall_ValidateScheduledTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want NGReason
+	}{
+		{"now", now, ""},
+		{"tomorrow", now.AddDate(0, 0, 1), ""},
+		{"past", now.Add(-time.Second), NGReasonPastTime},
+		{"at horizon", now.AddDate(0, 0, 30), ""},
+		{"beyond horizon", now.AddDate(0, 0, 30).Add(time.Second), NGReasonTooFarInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			morningCall := &MorningCall{Time: tt.t}
+			if got := morningCall.ValidateScheduledTime(now); got != tt.want {
+				t.Errorf("ValidateScheduledTime(%v) = %q, want %q", now, got, tt.want)
+			}
+		})
+	}
+}
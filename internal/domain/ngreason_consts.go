@@ -8,29 +8,63 @@ const (
 	NGReasonAlreadyRequested NGReason = "既にフレンド申請済みです。"
 	NGReasonBlocked          NGReason = "ブロックされています。"
 	NGReasonBlockedByUser    NGReason = "このユーザーをブロックしています。"
+	NGReasonNotBlocked       NGReason = "このユーザーをブロックしていません。"
 	NGReasonUserNotFound     NGReason = "ユーザーが見つかりません。"
 	NGReasonSelfOperation    NGReason = "自分自身に対する操作はできません。"
 	NGReasonNoPermission     NGReason = "権限がありません。"
 	NGReasonInvalidStatus    NGReason = "無効なステータスです。"
 	NGReasonPendingRequest   NGReason = "承認待ちのリクエストがあります。"
+	NGReasonNoPendingRequest NGReason = "承認待ちのフレンド申請がありません。"
+
+	// 認証関連のNGReason
+	NGReasonInvalidCredentials NGReason = "メールアドレスまたはパスワードが正しくありません。"
+	NGReasonSessionExpired     NGReason = "セッションの有効期限が切れています。"
 
 	// MorningCall関連のNGReason
 	NGReasonInvalidTime         NGReason = "無効な時刻設定です。"
+	NGReasonOutsideMorningHours NGReason = "受信者の現地時刻でモーニングコールの時間帯（4時〜12時）ではありません。"
 	NGReasonPastTime            NGReason = "過去の時刻は設定できません。"
 	NGReasonTooFarInFuture      NGReason = "設定可能な期間を超えています。"
 	NGReasonAlreadyCompleted    NGReason = "既に完了しています。"
 	NGReasonAlreadyDeleted      NGReason = "既に削除されています。"
+	NGReasonNotDeleted          NGReason = "削除されていません。"
+	NGReasonRestoreExpired      NGReason = "復元できる期間を過ぎています。"
 	NGReasonNotSender           NGReason = "送信者ではありません。"
 	NGReasonNotReceiver         NGReason = "受信者ではありません。"
 	NGReasonMorningCallNotFound NGReason = "モーニングコールが見つかりません。"
 	NGReasonDuplicateSchedule   NGReason = "同じ時刻に既にモーニングコールが設定されています。"
+	NGReasonScheduleTooClose    NGReason = "近い時刻に既にモーニングコールが設定されています。"
+	NGReasonNotDelivered        NGReason = "まだ配信されていません。"
+	NGReasonAlreadyAcknowledged NGReason = "既に応答済みです。"
+	NGReasonAckDeadlinePassed   NGReason = "応答期限を過ぎています。"
+	NGReasonSnoozeLimitReached  NGReason = "スヌーズの上限回数に達しています。"
+	NGReasonInvalidSnooze       NGReason = "無効なスヌーズ時間です。"
+	NGReasonNotOccurrence       NGReason = "繰り返し設定に含まれない日時です。"
+	NGReasonSeriesCancelled     NGReason = "繰り返し設定は既に終了しています。"
 
 	// バリデーション関連のNGReason
 	NGReasonInvalidEmail     NGReason = "無効なメールアドレス形式です。"
 	NGReasonInvalidUsername  NGReason = "無効なユーザー名です。"
+	NGReasonInvalidPassword  NGReason = "パスワードは8文字以上128文字以下で設定してください。"
 	NGReasonUsernameTooShort NGReason = "ユーザー名が短すぎます。"
 	NGReasonUsernameTooLong  NGReason = "ユーザー名が長すぎます。"
 	NGReasonMessageTooLong   NGReason = "メッセージが長すぎます。"
 	NGReasonEmptyMessage     NGReason = "メッセージが空です。"
 	NGReasonInvalidParameter NGReason = "無効なパラメータです。"
+	NGReasonInvalidTimeZone  NGReason = "無効なタイムゾーンです。"
+
+	// 通知設定関連のNGReason
+	NGReasonInvalidChannel    NGReason = "無効な通知チャネルです。"
+	NGReasonInvalidWebhookURL NGReason = "無効なWebhook URLです。"
+
+	// 受信設定関連のNGReason
+	NGReasonCallerNotAllowed   NGReason = "受信者がこのユーザーからのモーニングコールを受け付けていません。"
+	NGReasonOutsideWakeWindow  NGReason = "受信者が受け付ける時間帯ではありません。"
+	NGReasonReceiverOnVacation NGReason = "受信者がモーニングコールを休止している期間です。"
+	NGReasonDailyLimitReached  NGReason = "受信者の1日あたりの受信件数の上限に達しています。"
+	NGReasonInvalidWakeWindow  NGReason = "無効な時間帯です。"
+	NGReasonInvalidDailyLimit  NGReason = "無効な1日あたりの上限件数です。"
+	NGReasonInvalidMinimumGap  NGReason = "無効な最小間隔です。"
+	NGReasonInvalidVacation    NGReason = "無効な休止期間です。"
+	NGReasonInvalidCallerList  NGReason = "無効な許可・拒否ユーザーの指定です。"
 )
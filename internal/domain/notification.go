@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// NotificationChannel はモーニングコールの配信経路です
+type NotificationChannel string
+
+const (
+	NotificationChannelLog     NotificationChannel = "log"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+	NotificationChannelEmail   NotificationChannel = "email"
+)
+
+// IsValid checks if the notification channel is known
+func (rcv NotificationChannel) IsValid() bool {
+	switch rcv {
+	case NotificationChannelLog, NotificationChannelWebhook, NotificationChannelEmail:
+		return true
+	default:
+		return false
+	}
+}
+
+// 受信者ごとの通知設定
+type NotificationSettings struct {
+	Channels   []NotificationChannel
+	WebhookURL string
+}
+
+// DefaultNotificationSettings returns the settings given to newly registered users
+func DefaultNotificationSettings() NotificationSettings {
+	return NotificationSettings{
+		Channels: []NotificationChannel{NotificationChannelLog},
+	}
+}
+
+// Validate checks if the notification settings are consistent
+func (rcv NotificationSettings) Validate() NGReason {
+	if len(rcv.Channels) == 0 {
+		return NGReasonInvalidChannel
+	}
+
+	seen := make(map[NotificationChannel]bool, len(rcv.Channels))
+	for _, ch := range rcv.Channels {
+		if !ch.IsValid() || seen[ch] {
+			return NGReasonInvalidChannel
+		}
+		seen[ch] = true
+	}
+
+	// Webhookを使う場合はURLが必須
+	if seen[NotificationChannelWebhook] || rcv.WebhookURL != "" {
+		u, err := url.Parse(rcv.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NGReasonInvalidWebhookURL
+		}
+		// 内部ネットワーク宛てのURLは登録時点で拒否する
+		// 名前解決後のアドレスは配信時に Notifier が改めて確認する
+		if isInternalHost(u.Hostname()) {
+			return NGReasonInvalidWebhookURL
+		}
+	}
+
+	return ""
+}
+
+// internalWebhookPrefixes は IsPublicWebhookAddr が net/netip の判定に加えて拒否する範囲です
+var internalWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // このネットワーク
+	netip.MustParsePrefix("100.64.0.0/10"), // キャリアグレードNAT
+}
+
+// IsPublicWebhookAddr reports whether webhooks may be delivered to addr
+// Loopback, private (RFC 1918, unique local), link-local, multicast and unspecified addresses are refused
+// so that a user-supplied webhook URL cannot reach the server's own network.
+func IsPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range internalWebhookPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInternalHost はホスト名が localhost か、公開されていないIPアドレスの場合に true を返します
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return !IsPublicWebhookAddr(addr.WithZone(""))
+	}
+	return false
+}
+
+// HasChannel checks if the channel is enabled
+func (rcv NotificationSettings) HasChannel(channel NotificationChannel) bool {
+	for _, ch := range rcv.Channels {
+		if ch == channel {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestNotificationSettings_ValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want NGReason
+	}{
+		{"https://hooks.example.com/morning", ""},
+		{"http://203.0.113.10:8080/hook", ""},
+		{"ftp://hooks.example.com/morning", NGReasonInvalidWebhookURL},
+		{"https:///morning", NGReasonInvalidWebhookURL},
+		{"http://localhost:8080/hook", NGReasonInvalidWebhookURL},
+		{"http://api.localhost/hook", NGReasonInvalidWebhookURL},
+		{"http://127.0.0.1/hook", NGReasonInvalidWebhookURL},
+		{"http://10.0.0.5/hook", NGReasonInvalidWebhookURL},
+		{"http://172.16.0.1/hook", NGReasonInvalidWebhookURL},
+		{"http://192.168.1.1/hook", NGReasonInvalidWebhookURL},
+		{"http://169.254.169.254/latest/meta-data", NGReasonInvalidWebhookURL},
+		{"http://0.0.0.0/hook", NGReasonInvalidWebhookURL},
+		{"http://[::1]/hook", NGReasonInvalidWebhookURL},
+		{"http://[fe80::1%25eth0]/hook", NGReasonInvalidWebhookURL},
+		{"http://[::ffff:127.0.0.1]/hook", NGReasonInvalidWebhookURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			settings := NotificationSettings{
+				Channels:   []NotificationChannel{NotificationChannelWebhook},
+				WebhookURL: tt.url,
+			}
+			if got := settings.Validate(); got != tt.want {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPublicWebhookAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2001:db8::1", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.31.255.255", false},
+		{"192.168.0.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := IsPublicWebhookAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("IsPublicWebhookAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// minutesPerDay は1日の分数です
+const minutesPerDay = 24 * 60
+
+// MaxMinimumGap は受信者が設定できるモーニングコール同士の最小間隔の上限です
+const MaxMinimumGap = 12 * time.Hour
+
+// TimeOfDay は0時からの経過分で表す時刻です
+type TimeOfDay int
+
+// ParseTimeOfDay parses "HH:MM". "24:00" is accepted as the end of the day
+func ParseTimeOfDay(s string) (TimeOfDay, bool) {
+	if s == "24:00" {
+		return minutesPerDay, true
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return TimeOfDay(t.Hour()*60 + t.Minute()), true
+}
+
+// TimeOfDayOf returns the wall-clock time of t in its location
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay(t.Hour()*60 + t.Minute())
+}
+
+// String returns the time as "HH:MM"
+func (rcv TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(rcv)/60, int(rcv)%60)
+}
+
+// WakeWindow は受信者がモーニングコールを受け付ける時間帯 [Start, End) です
+type WakeWindow struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// IsZero reports whether the window is unset, which means no restriction
+func (rcv WakeWindow) IsZero() bool {
+	return rcv.Start == 0 && rcv.End == 0
+}
+
+// Contains checks if the time of day is within the window
+func (rcv WakeWindow) Contains(t TimeOfDay) bool {
+	return rcv.IsZero() || (rcv.Start <= t && t < rcv.End)
+}
+
+// 受信者が自分宛てのモーニングコールを制御する設定
+// ゼロ値はすべてのフレンドから制限なく受け付ける
+type ReceiverPreferences struct {
+	// WakeWindow は受け付ける時間帯（受信者のタイムゾーン）。ゼロ値の場合は制限なし
+	WakeWindow WakeWindow
+	// DailyLimit は1日（受信者のタイムゾーン）に受け付ける件数の上限。0 の場合は制限なし
+	DailyLimit int
+	// AllowedCallers が空でなければ、含まれるフレンドからのみ受け付ける
+	AllowedCallers []UserID
+	// DeniedCallers に含まれるフレンドからは受け付けない
+	DeniedCallers []UserID
+	// VacationFrom から VacationUntil までの時刻は受け付けない。ゼロ値の場合は休止なし
+	VacationFrom  time.Time
+	VacationUntil time.Time
+	// MinimumGap は他のモーニングコールとの最小間隔。0 の場合は同時刻の重複のみを認めない
+	MinimumGap time.Duration
+}
+
+// Validate checks if the preferences are consistent
+func (rcv ReceiverPreferences) Validate() NGReason {
+	if !rcv.WakeWindow.IsZero() {
+		if rcv.WakeWindow.Start < 0 || rcv.WakeWindow.End > minutesPerDay || rcv.WakeWindow.Start >= rcv.WakeWindow.End {
+			return NGReasonInvalidWakeWindow
+		}
+	}
+
+	if rcv.DailyLimit < 0 {
+		return NGReasonInvalidDailyLimit
+	}
+
+	if rcv.MinimumGap < 0 || rcv.MinimumGap > MaxMinimumGap {
+		return NGReasonInvalidMinimumGap
+	}
+
+	// 休止期間は開始・終了の両方を指定する
+	if rcv.VacationFrom.IsZero() != rcv.VacationUntil.IsZero() {
+		return NGReasonInvalidVacation
+	}
+	if !rcv.VacationFrom.IsZero() && !rcv.VacationUntil.After(rcv.VacationFrom) {
+		return NGReasonInvalidVacation
+	}
+
+	// 同じユーザーの重複や、許可と拒否の両方への指定は認めない
+	seen := make(map[UserID]bool, len(rcv.AllowedCallers)+len(rcv.DeniedCallers))
+	for _, id := range slices.Concat(rcv.AllowedCallers, rcv.DeniedCallers) {
+		if id == "" || seen[id] {
+			return NGReasonInvalidCallerList
+		}
+		seen[id] = true
+	}
+
+	return ""
+}
+
+// AcceptsCaller checks if the receiver accepts morning calls from the sender
+func (rcv ReceiverPreferences) AcceptsCaller(senderID UserID) bool {
+	if slices.Contains(rcv.DeniedCallers, senderID) {
+		return false
+	}
+	return len(rcv.AllowedCallers) == 0 || slices.Contains(rcv.AllowedCallers, senderID)
+}
+
+// IsOnVacation checks if t falls within the vacation pause
+func (rcv ReceiverPreferences) IsOnVacation(t time.Time) bool {
+	if rcv.VacationFrom.IsZero() {
+		return false
+	}
+	return !t.Before(rcv.VacationFrom) && t.Before(rcv.VacationUntil)
+}
+
+// IsDailyLimitReached checks if count calls on the same day already reach the daily limit
+func (rcv ReceiverPreferences) IsDailyLimitReached(count int) bool {
+	return rcv.DailyLimit > 0 && count >= rcv.DailyLimit
+}
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecurrenceRule indicates the RRULE could not be parsed
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+// RecurrenceFrequency is the FREQ part of a recurrence rule
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyDaily  RecurrenceFrequency = "DAILY"
+	RecurrenceFrequencyWeekly RecurrenceFrequency = "WEEKLY"
+)
+
+// recurrenceUntilLayout is the UTC date-time form of UNTIL
+const recurrenceUntilLayout = "20060102T150405Z"
+
+// maxRecurrenceIterations bounds expansion of rules that never match (e.g. DAILY with an empty BYDAY intersection)
+const maxRecurrenceIterations = 100000
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RecurrenceRule is the supported subset of an RFC 5545 RRULE:
+// FREQ (DAILY, WEEKLY), BYDAY, INTERVAL, UNTIL and COUNT.
+// Weeks start on Monday.
+type RecurrenceRule struct {
+	Freq     RecurrenceFrequency
+	Interval int
+	ByDay    []time.Weekday
+	Until    time.Time
+	Count    int
+}
+
+// ParseRecurrenceRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+// An optional "RRULE:" prefix is accepted. UNTIL must be a UTC date-time or a date,
+// in which case the whole day (UTC) is included.
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	rule := RecurrenceRule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return RecurrenceRule{}, fmt.Errorf("%w: empty rule", ErrInvalidRecurrenceRule)
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || val == "" {
+			return RecurrenceRule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrenceRule, part)
+		}
+		if seen[name] {
+			return RecurrenceRule{}, fmt.Errorf("%w: duplicate %s", ErrInvalidRecurrenceRule, name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			rule.Freq = RecurrenceFrequency(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return RecurrenceRule{}, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrenceRule)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return RecurrenceRule{}, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRecurrenceRule)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(val)
+			if err != nil {
+				return RecurrenceRule{}, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return RecurrenceRule{}, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRecurrenceRule, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		default:
+			return RecurrenceRule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrenceRule, name)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return rule, nil
+}
+
+func parseRecurrenceUntil(val string) (time.Time, error) {
+	if t, err := time.Parse(recurrenceUntilLayout, val); err == nil {
+		return t, nil
+	}
+	if d, err := time.Parse("20060102", val); err == nil {
+		return d.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRecurrenceRule)
+}
+
+// Validate checks the rule for consistency
+func (rcv RecurrenceRule) Validate() error {
+	switch rcv.Freq {
+	case RecurrenceFrequencyDaily, RecurrenceFrequencyWeekly:
+	case "":
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrenceRule)
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRecurrenceRule, rcv.Freq)
+	}
+	if rcv.Interval < 1 {
+		return fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrenceRule)
+	}
+	if rcv.Count > 0 && !rcv.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrenceRule)
+	}
+	return nil
+}
+
+// String formats the rule as an RRULE value
+func (rcv RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(rcv.Freq)}
+	if rcv.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(rcv.Interval))
+	}
+	if len(rcv.ByDay) > 0 {
+		codes := make([]string, 0, len(rcv.ByDay))
+		for _, day := range sortedWeekdays(rcv.ByDay) {
+			for code, d := range weekdayCodes {
+				if d == day {
+					codes = append(codes, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if rcv.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(rcv.Count))
+	}
+	if !rcv.Until.IsZero() {
+		parts = append(parts, "UNTIL="+rcv.Until.UTC().Format(recurrenceUntilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Expand calls fn for each occurrence starting at start in chronological order
+// until fn returns false, the rule ends, or an occurrence is after limit.
+// Occurrences keep the wall-clock time of start in start's location, so a 07:00
+// series stays at 07:00 local time across DST changes.
+func (rcv RecurrenceRule) Expand(start, limit time.Time, fn func(time.Time) bool) {
+	interval := rcv.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return true
+		}
+		if t.After(limit) || (!rcv.Until.IsZero() && t.After(rcv.Until)) {
+			return false
+		}
+		if rcv.Count > 0 && emitted >= rcv.Count {
+			return false
+		}
+		emitted++
+		return fn(t)
+	}
+
+	switch rcv.Freq {
+	case RecurrenceFrequencyDaily:
+		for i := 0; i < maxRecurrenceIterations; i++ {
+			t := addDays(start, i*interval)
+			if len(rcv.ByDay) > 0 && !containsWeekday(rcv.ByDay, t.Weekday()) {
+				if t.After(limit) {
+					return
+				}
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		}
+
+	case RecurrenceFrequencyWeekly:
+		days := rcv.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		days = sortedWeekdays(days)
+
+		// 週の開始（月曜日）からのオフセットで各曜日を求める
+		weekStart := addDays(start, -mondayOffset(start.Weekday()))
+		for week := 0; week < maxRecurrenceIterations; week++ {
+			base := addDays(weekStart, week*7*interval)
+			for _, day := range days {
+				if !emit(addDays(base, mondayOffset(day))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Occurrences returns the occurrences of the rule starting at start that fall within [from, to]
+func (rcv RecurrenceRule) Occurrences(start, from, to time.Time) []time.Time {
+	var result []time.Time
+	rcv.Expand(start, to, func(t time.Time) bool {
+		if !t.Before(from) {
+			result = append(result, t)
+		}
+		return true
+	})
+	return result
+}
+
+// addDays adds n calendar days keeping the wall-clock time in t's location
+func addDays(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+n, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// mondayOffset returns the number of days since Monday
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedWeekdays returns the unique weekdays ordered from Monday to Sunday
+func sortedWeekdays(days []time.Weekday) []time.Weekday {
+	seen := make(map[time.Weekday]bool, len(days))
+	result := make([]time.Weekday, 0, len(days))
+	for _, d := range days {
+		if !seen[d] {
+			seen[d] = true
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return mondayOffset(result[i]) < mondayOffset(result[j])
+	})
+	return result
+}
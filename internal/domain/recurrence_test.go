@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		value string
+		want  RecurrenceRule
+	}{
+		{"FREQ=DAILY", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1}},
+		{"RRULE:FREQ=DAILY;INTERVAL=2", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 2}},
+		{"freq=weekly;byday=mo,we,fr", RecurrenceRule{Freq: RecurrenceFrequencyWeekly, Interval: 1, ByDay: []time.Weekday{time.Monday, time.Wednesday, time.Friday}}},
+		{"FREQ=WEEKLY;COUNT=10", RecurrenceRule{Freq: RecurrenceFrequencyWeekly, Interval: 1, Count: 10}},
+		{"FREQ=DAILY;UNTIL=20261031T220000Z", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1, Until: time.Date(2026, 10, 31, 22, 0, 0, 0, time.UTC)}},
+		// 日付のみの UNTIL はその日の終わり (UTC) まで含む
+		{"FREQ=DAILY;UNTIL=20261031", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1, Until: time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRecurrenceRule(tt.value)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule: %v", err)
+			}
+			if got.Freq != tt.want.Freq || got.Interval != tt.want.Interval || got.Count != tt.want.Count ||
+				!got.Until.Equal(tt.want.Until) || !slices.Equal(got.ByDay, tt.want.ByDay) {
+				t.Errorf("ParseRecurrenceRule = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceRule_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"RRULE:",
+		"FREQ",
+		"FREQ=",
+		"BYDAY=MO",
+		"FREQ=MONTHLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;UNTIL=2026-10-31",
+		"FREQ=DAILY;COUNT=3;UNTIL=20261031",
+		"FREQ=WEEKLY;BYDAY=MO,XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;BYMONTH=1",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			if _, err := ParseRecurrenceRule(value); !errors.Is(err, ErrInvalidRecurrenceRule) {
+				t.Errorf("ParseRecurrenceRule error = %v, want %v", err, ErrInvalidRecurrenceRule)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_Validate(t *testing.T) {
+	until := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rule    RecurrenceRule
+		wantErr bool
+	}{
+		{"daily", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1}, false},
+		{"weekly with count", RecurrenceRule{Freq: RecurrenceFrequencyWeekly, Interval: 2, Count: 4}, false},
+		{"until", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1, Until: until}, false},
+		{"missing freq", RecurrenceRule{Interval: 1}, true},
+		{"unsupported freq", RecurrenceRule{Freq: "YEARLY", Interval: 1}, true},
+		{"zero interval", RecurrenceRule{Freq: RecurrenceFrequencyDaily}, true},
+		{"count and until", RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1, Count: 2, Until: until}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_StringRoundTrip(t *testing.T) {
+	tests := []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=3;COUNT=5",
+		"FREQ=WEEKLY;BYDAY=MO,WE,FR;UNTIL=20261031T220000Z",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(value)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule: %v", err)
+			}
+			if got := rule.String(); got != value {
+				t.Errorf("String() = %q, want %q", got, value)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_Occurrences(t *testing.T) {
+	// 2026-10-19 は月曜日
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return addDays(start, d) }
+
+	tests := []struct {
+		rule     string
+		from, to time.Time
+		want     []time.Time
+	}{
+		{"FREQ=DAILY", start, day(3), []time.Time{day(0), day(1), day(2), day(3)}},
+		{"FREQ=DAILY;INTERVAL=2", start, day(6), []time.Time{day(0), day(2), day(4), day(6)}},
+		{"FREQ=DAILY;COUNT=3", start, day(30), []time.Time{day(0), day(1), day(2)}},
+		// COUNT は from より前の発生日時も数える
+		{"FREQ=DAILY;COUNT=3", day(1), day(30), []time.Time{day(1), day(2)}},
+		// UNTIL ちょうどの発生日時は含む
+		{"FREQ=DAILY;UNTIL=20261021T070000Z", start, day(30), []time.Time{day(0), day(1), day(2)}},
+		{"FREQ=DAILY;UNTIL=20261021T065959Z", start, day(30), []time.Time{day(0), day(1)}},
+		{"FREQ=DAILY;UNTIL=20261021", start, day(30), []time.Time{day(0), day(1), day(2)}},
+		{"FREQ=DAILY;BYDAY=SA,SU", start, day(13), []time.Time{day(5), day(6), day(12), day(13)}},
+		{"FREQ=WEEKLY", start, day(21), []time.Time{day(0), day(7), day(14), day(21)}},
+		{"FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4", start, day(30), []time.Time{day(0), day(2), day(4), day(7)}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", start, day(20), []time.Time{day(1), day(3), day(15), day(17)}},
+		// 週は月曜日に始まるため、日曜日は同じ週の最後になる
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU", start, day(20), []time.Time{day(0), day(6), day(14), day(20)}},
+		// 初回より前の曜日は翌週から始まり、COUNT にも数えない
+		{"FREQ=WEEKLY;BYDAY=SU;COUNT=2", day(-7), day(30), []time.Time{day(6), day(13)}},
+		// 範囲の端は両方とも含む
+		{"FREQ=DAILY", day(1), day(2), []time.Time{day(1), day(2)}},
+		{"FREQ=DAILY", day(1).Add(time.Second), day(2).Add(-time.Second), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule: %v", err)
+			}
+			got := rule.Occurrences(start, tt.from, tt.to)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("Occurrences(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_ExpandStopsWhenFnReturnsFalse(t *testing.T) {
+	rule := RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 1}
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+
+	calls := 0
+	rule.Expand(start, start.AddDate(1, 0, 0), func(time.Time) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestRecurrenceRule_ExpandTerminatesWithoutMatches(t *testing.T) {
+	// BYDAY が空の積集合になる設定でも上限で打ち切る
+	rule := RecurrenceRule{Freq: RecurrenceFrequencyDaily, Interval: 7, ByDay: []time.Weekday{time.Tuesday}}
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+
+	if got := rule.Occurrences(start, start, start.AddDate(1, 0, 0)); len(got) != 0 {
+		t.Errorf("Occurrences = %v, want none", got)
+	}
+}
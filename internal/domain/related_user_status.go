@@ -7,6 +7,12 @@ const (
 	RelatedUserStatusPending  RelatedUserStatus = "pending"
 	RelatedUserStatusRejected RelatedUserStatus = "rejected"
 	RelatedUserStatusBlocked  RelatedUserStatus = "blocked"
+	// 申請者が取り消した申請
+	RelatedUserStatusCancelled RelatedUserStatus = "cancelled"
+	// どちらかが解消したフレンド関係
+	RelatedUserStatusUnfriended RelatedUserStatus = "unfriended"
+	// 有効期限までに応答のなかった申請
+	RelatedUserStatusExpired RelatedUserStatus = "expired"
 )
 
 // CanTransitionTo checks if the status can transition to the target status
@@ -18,23 +24,25 @@ func (rcv RelatedUserStatus) CanTransitionTo(target RelatedUserStatus) NGReason
 
 	switch rcv {
 	case RelatedUserStatusPending:
-		// Pending → Approved, Rejected, Blocked
+		// Pending → Approved, Rejected, Cancelled (申請者による取り消し), Expired (有効期限切れ), Blocked
 		switch target {
-		case RelatedUserStatusApproved, RelatedUserStatusRejected, RelatedUserStatusBlocked:
+		case RelatedUserStatusApproved, RelatedUserStatusRejected, RelatedUserStatusCancelled, RelatedUserStatusExpired, RelatedUserStatusBlocked:
 			return ""
 		default:
 			return NGReasonInvalidStatus
 		}
 
 	case RelatedUserStatusApproved:
-		// Approved → Blocked のみ可能
-		if target == RelatedUserStatusBlocked {
+		// Approved → Unfriended (フレンド解消), Blocked
+		switch target {
+		case RelatedUserStatusUnfriended, RelatedUserStatusBlocked:
 			return ""
+		default:
+			return NGReasonInvalidStatus
 		}
-		return NGReasonInvalidStatus
 
-	case RelatedUserStatusRejected:
-		// Rejected → Pending (再申請), Blocked
+	case RelatedUserStatusRejected, RelatedUserStatusCancelled, RelatedUserStatusUnfriended, RelatedUserStatusExpired:
+		// Rejected, Cancelled, Unfriended, Expired → Pending (再申請), Blocked
 		switch target {
 		case RelatedUserStatusPending, RelatedUserStatusBlocked:
 			return ""
@@ -11,11 +11,11 @@ type Relationship struct {
 	Status      RelationshipStatus
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Version     int // Incremented by the repository on every update for optimistic concurrency
 }
 
 // NewRelationship creates a new relationship with pending status
-func NewRelationship(requesterID, receiverID UserID) *Relationship {
-	now := time.Now()
+func NewRelationship(requesterID, receiverID UserID, now time.Time) *Relationship {
 	return &Relationship{
 		ID:          NewRelationshipID(),
 		RequesterID: requesterID,
@@ -37,29 +37,64 @@ func (r *Relationship) CanReject(userID UserID) bool {
 }
 
 // Approve approves the relationship
-func (r *Relationship) Approve() error {
+func (r *Relationship) Approve(now time.Time) error {
 	if r.Status != RelationshipStatusPending {
 		return ErrInvalidRelationshipStatus
 	}
 	r.Status = RelationshipStatusApproved
-	r.UpdatedAt = time.Now()
+	r.UpdatedAt = now
 	return nil
 }
 
 // Reject rejects the relationship
-func (r *Relationship) Reject() error {
+func (r *Relationship) Reject(now time.Time) error {
 	if r.Status != RelationshipStatusPending {
 		return ErrInvalidRelationshipStatus
 	}
 	r.Status = RelationshipStatusRejected
-	r.UpdatedAt = time.Now()
+	r.UpdatedAt = now
 	return nil
 }
 
+// Cancel withdraws the friend request
+func (r *Relationship) Cancel(now time.Time) error {
+	if r.Status != RelationshipStatusPending {
+		return ErrInvalidRelationshipStatus
+	}
+	r.Status = RelationshipStatusCancelled
+	r.UpdatedAt = now
+	return nil
+}
+
+// Unfriend ends the friendship
+func (r *Relationship) Unfriend(now time.Time) error {
+	if r.Status != RelationshipStatusApproved {
+		return ErrInvalidRelationshipStatus
+	}
+	r.Status = RelationshipStatusUnfriended
+	r.UpdatedAt = now
+	return nil
+}
+
+// Expire expires the friend request
+func (r *Relationship) Expire(now time.Time) error {
+	if r.Status != RelationshipStatusPending {
+		return ErrInvalidRelationshipStatus
+	}
+	r.Status = RelationshipStatusExpired
+	r.UpdatedAt = now
+	return nil
+}
+
+// IsStale checks if the friend request has been pending for ttl or longer
+func (r *Relationship) IsStale(ttl time.Duration, now time.Time) bool {
+	return r.IsPending() && !r.CreatedAt.Add(ttl).After(now)
+}
+
 // Block blocks the relationship
-func (r *Relationship) Block() {
+func (r *Relationship) Block(now time.Time) {
 	r.Status = RelationshipStatusBlocked
-	r.UpdatedAt = time.Now()
+	r.UpdatedAt = now
 }
 
 // IsFriend checks if the relationship represents a friendship
@@ -15,6 +15,15 @@ const (
 
 	// RelationshipStatusBlocked indicates a blocked relationship
 	RelationshipStatusBlocked RelationshipStatus = "blocked"
+
+	// RelationshipStatusCancelled indicates a friend request withdrawn by the requester
+	RelationshipStatusCancelled RelationshipStatus = "cancelled"
+
+	// RelationshipStatusUnfriended indicates a friendship ended by either user
+	RelationshipStatusUnfriended RelationshipStatus = "unfriended"
+
+	// RelationshipStatusExpired indicates a friend request left pending past its TTL
+	RelationshipStatusExpired RelationshipStatus = "expired"
 )
 
 // IsValid checks if the relationship status is valid
@@ -23,7 +32,10 @@ func (s RelationshipStatus) IsValid() bool {
 	case RelationshipStatusPending,
 		RelationshipStatusApproved,
 		RelationshipStatusRejected,
-		RelationshipStatusBlocked:
+		RelationshipStatusBlocked,
+		RelationshipStatusCancelled,
+		RelationshipStatusUnfriended,
+		RelationshipStatusExpired:
 		return true
 	default:
 		return false
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRelationship_Transitions(t *testing.T) {
+	created := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	now := created.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		from       RelationshipStatus
+		transition func(*Relationship, time.Time) error
+		want       RelationshipStatus
+	}{
+		{name: "approve", from: RelationshipStatusPending, transition: (*Relationship).Approve, want: RelationshipStatusApproved},
+		{name: "reject", from: RelationshipStatusPending, transition: (*Relationship).Reject, want: RelationshipStatusRejected},
+		{name: "cancel", from: RelationshipStatusPending, transition: (*Relationship).Cancel, want: RelationshipStatusCancelled},
+		{name: "expire", from: RelationshipStatusPending, transition: (*Relationship).Expire, want: RelationshipStatusExpired},
+		{name: "unfriend", from: RelationshipStatusApproved, transition: (*Relationship).Unfriend, want: RelationshipStatusUnfriended},
+		{name: "block", from: RelationshipStatusApproved, transition: func(r *Relationship, now time.Time) error {
+			r.Block(now)
+			return nil
+		}, want: RelationshipStatusBlocked},
+		{name: "approve approved", from: RelationshipStatusApproved, transition: (*Relationship).Approve},
+		{name: "cancel rejected", from: RelationshipStatusRejected, transition: (*Relationship).Cancel},
+		{name: "unfriend pending", from: RelationshipStatusPending, transition: (*Relationship).Unfriend},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel := NewRelationship("alice", "bob", created)
+			rel.Status = tt.from
+
+			err := tt.transition(rel, now)
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalidRelationshipStatus) {
+					t.Errorf("error = %v, want %v", err, ErrInvalidRelationshipStatus)
+				}
+				if rel.Status != tt.from || !rel.UpdatedAt.Equal(created) {
+					t.Errorf("status, UpdatedAt = %s, %v, want them unchanged", rel.Status, rel.UpdatedAt)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("transition: %v", err)
+			}
+			if rel.Status != tt.want || !rel.UpdatedAt.Equal(now) || !rel.CreatedAt.Equal(created) {
+				t.Errorf("status, CreatedAt, UpdatedAt = %s, %v, %v, want %s, %v, %v", rel.Status, rel.CreatedAt, rel.UpdatedAt, tt.want, created, now)
+			}
+		})
+	}
+}
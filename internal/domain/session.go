@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Session represents an authenticated login of a user
+// Only the hash of the bearer token is kept
+type Session struct {
+	TokenHash string
+	UserID    UserID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// IsExpired checks if the session is expired at the given time
+func (rcv *Session) IsExpired(now time.Time) bool {
+	return !now.Before(rcv.ExpiresAt)
+}
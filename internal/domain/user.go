@@ -1,10 +1,41 @@
 package domain
 
+import "time"
+
+// DefaultTimeZone is the time zone given to users who have not chosen one
+const DefaultTimeZone = "UTC"
+
 // システムの利用者
 type User struct {
 	ID           UserID
 	Username     string
 	Email        string
+	PasswordHash string
+	// TimeZone は IANA タイムゾーン名（例: Asia/Tokyo）。時間帯のルールはこのタイムゾーンで判定する
+	TimeZone     string
+	Notification NotificationSettings
+	// Preferences は受信者として自分宛てのモーニングコールを制御する設定
+	Preferences  ReceiverPreferences
 	MorningCalls []MorningCall
+	// Deprecated: フレンド関係は Relationship で管理する。移行前のデータとしてのみ保持する
 	RelatedUsers []RelatedUser
+	// Version は更新のたびにリポジトリが加算する。古いバージョンでの更新は競合として拒否される
+	Version int
+}
+
+// Location returns the user's time zone, falling back to UTC when unset or unknown
+func (rcv *User) Location() *time.Location {
+	return LoadLocation(rcv.TimeZone)
+}
+
+// LoadLocation loads an IANA time zone, falling back to UTC when the name is empty or unknown
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
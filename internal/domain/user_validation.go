@@ -1,42 +1,73 @@
 package domain
 
+import "time"
+
+// 以下のチェックは対象ユーザーとの間の Relationship を受け取って判定する
+// relationships には RelationshipRepository.FindAllByUsers の結果を渡すこと
+
 // CanAcceptMorningCall checks if the user can accept a morning call from the specified friend
-func (rcv *User) CanAcceptMorningCall(friendID UserID) NGReason {
-	for _, ru := range rcv.RelatedUsers {
-		if ru.ID == friendID {
-			if ru.Status == RelatedUserStatusApproved {
-				return ""
-			}
-			if ru.Status == RelatedUserStatusBlocked {
-				return NGReasonBlocked
-			}
-			return NGReasonNotFriend
+func (rcv *User) CanAcceptMorningCall(friendID UserID, relationships []*Relationship) NGReason {
+	isFriend := false
+	for _, rel := range relationshipsWith(rcv.ID, friendID, relationships) {
+		if rel.IsBlocked() {
+			return NGReasonBlocked
+		}
+		if rel.IsFriend() {
+			isFriend = true
 		}
 	}
-	return NGReasonNotFriend
+
+	if !isFriend {
+		return NGReasonNotFriend
+	}
+	return ""
+}
+
+// CanReceiveMorningCall checks the receiver's preferences for a morning call from the sender at t
+// sameDayCount is the number of morning calls the receiver already has on the same local day
+func (rcv *User) CanReceiveMorningCall(senderID UserID, t time.Time, sameDayCount int) NGReason {
+	prefs := rcv.Preferences
+
+	if !prefs.AcceptsCaller(senderID) {
+		return NGReasonCallerNotAllowed
+	}
+	if prefs.IsOnVacation(t) {
+		return NGReasonReceiverOnVacation
+	}
+	if !prefs.WakeWindow.Contains(TimeOfDayOf(t.In(rcv.Location()))) {
+		return NGReasonOutsideWakeWindow
+	}
+	if prefs.IsDailyLimitReached(sameDayCount) {
+		return NGReasonDailyLimitReached
+	}
+	return ""
 }
 
 // CanAddFriend checks if the user can add a friend
-func (rcv *User) CanAddFriend(targetUserID UserID) NGReason {
+func (rcv *User) CanAddFriend(targetUserID UserID, relationships []*Relationship) NGReason {
 	// 自分自身は追加できない
 	if rcv.ID == targetUserID {
 		return NGReasonSelfOperation
 	}
 
 	// 既存の関係をチェック
-	for _, ru := range rcv.RelatedUsers {
-		if ru.ID == targetUserID {
-			switch ru.Status {
-			case RelatedUserStatusApproved:
-				return NGReasonAlreadyFriend
-			case RelatedUserStatusPending:
+	for _, rel := range relationshipsWith(rcv.ID, targetUserID, relationships) {
+		switch rel.Status {
+		case RelationshipStatusApproved:
+			return NGReasonAlreadyFriend
+		case RelationshipStatusPending:
+			if rel.RequesterID == rcv.ID {
 				return NGReasonAlreadyRequested
-			case RelatedUserStatusBlocked:
+			}
+			// 相手からの申請が承認待ち
+			return NGReasonPendingRequest
+		case RelationshipStatusBlocked:
+			if rel.RequesterID == rcv.ID {
 				return NGReasonBlockedByUser
-			case RelatedUserStatusRejected:
-				// 拒否された場合は再申請可能
-				return ""
 			}
+			return NGReasonBlocked
+		case RelationshipStatusRejected, RelationshipStatusCancelled, RelationshipStatusUnfriended, RelationshipStatusExpired:
+			// 拒否・取り消し・解消・期限切れの場合は再申請可能
 		}
 	}
 
@@ -44,39 +75,105 @@ func (rcv *User) CanAddFriend(targetUserID UserID) NGReason {
 }
 
 // CanApproveFriend checks if the user can approve a friend request
-func (rcv *User) CanApproveFriend(requestingUserID UserID) NGReason {
-	for _, ru := range rcv.RelatedUsers {
-		if ru.ID == requestingUserID {
-			if ru.Status == RelatedUserStatusPending {
-				return ""
-			}
-			if ru.Status == RelatedUserStatusApproved {
-				return NGReasonAlreadyFriend
-			}
-			if ru.Status == RelatedUserStatusBlocked {
-				return NGReasonBlocked
-			}
-			return NGReasonInvalidStatus
+func (rcv *User) CanApproveFriend(requestingUserID UserID, relationships []*Relationship) NGReason {
+	var request *Relationship
+	for _, rel := range relationshipsWith(rcv.ID, requestingUserID, relationships) {
+		if rel.IsBlocked() {
+			return NGReasonBlocked
+		}
+		if rel.RequesterID == requestingUserID {
+			request = rel
 		}
 	}
-	return NGReasonUserNotFound
+
+	if request == nil {
+		return NGReasonUserNotFound
+	}
+
+	switch request.Status {
+	case RelationshipStatusPending:
+		return ""
+	case RelationshipStatusApproved:
+		return NGReasonAlreadyFriend
+	default:
+		return NGReasonInvalidStatus
+	}
 }
 
 // CanBlockUser checks if the user can block another user
-func (rcv *User) CanBlockUser(targetUserID UserID) NGReason {
+func (rcv *User) CanBlockUser(targetUserID UserID, relationships []*Relationship) NGReason {
 	// 自分自身はブロックできない
 	if rcv.ID == targetUserID {
 		return NGReasonSelfOperation
 	}
 
-	for _, ru := range rcv.RelatedUsers {
-		if ru.ID == targetUserID {
-			if ru.Status == RelatedUserStatusBlocked {
-				return NGReasonAlreadyRequested // 既にブロック済み
-			}
+	for _, rel := range relationshipsWith(rcv.ID, targetUserID, relationships) {
+		if rel.IsBlocked() && rel.RequesterID == rcv.ID {
+			return NGReasonAlreadyRequested // 既にブロック済み
+		}
+	}
+
+	return ""
+}
+
+// CanCancelFriendRequest checks if the user can withdraw their pending friend request to another user
+func (rcv *User) CanCancelFriendRequest(targetUserID UserID, relationships []*Relationship) NGReason {
+	if rcv.ID == targetUserID {
+		return NGReasonSelfOperation
+	}
+
+	for _, rel := range relationshipsWith(rcv.ID, targetUserID, relationships) {
+		if rel.IsPending() && rel.RequesterID == rcv.ID {
+			return ""
+		}
+	}
+	return NGReasonNoPendingRequest
+}
+
+// CanUnfriend checks if the user can end the friendship with another user
+func (rcv *User) CanUnfriend(friendID UserID, relationships []*Relationship) NGReason {
+	if rcv.ID == friendID {
+		return NGReasonSelfOperation
+	}
+
+	for _, rel := range relationshipsWith(rcv.ID, friendID, relationships) {
+		if rel.IsFriend() {
 			return ""
 		}
 	}
+	return NGReasonNotFriend
+}
+
+// CanUnblockUser checks if the user can lift a block on another user
+// Only the user's own block is lifted; a block placed by the other user stays in effect
+func (rcv *User) CanUnblockUser(targetUserID UserID, relationships []*Relationship) NGReason {
+	if rcv.ID == targetUserID {
+		return NGReasonSelfOperation
+	}
 
+	if BlockBy(rcv.ID, targetUserID, relationships) == nil {
+		return NGReasonNotBlocked
+	}
 	return ""
 }
+
+// BlockBy returns the block placed by blockerID on blockedID, or nil if there is none
+func BlockBy(blockerID, blockedID UserID, relationships []*Relationship) *Relationship {
+	for _, rel := range relationships {
+		if rel.IsBlocked() && rel.RequesterID == blockerID && rel.ReceiverID == blockedID {
+			return rel
+		}
+	}
+	return nil
+}
+
+// relationshipsWith filters the relationships between the two users
+func relationshipsWith(userID, otherUserID UserID, relationships []*Relationship) []*Relationship {
+	var result []*Relationship
+	for _, rel := range relationships {
+		if rel.InvolvesUser(userID) && rel.GetOtherUserID(userID) == otherUserID {
+			result = append(result, rel)
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/usecase"
+)
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditUsecase
+}
+
+func NewAuditHandler(auditUsecase usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUsecase: auditUsecase,
+	}
+}
+
+// auditEntryResponse is the JSON representation of an audit log entry
+type auditEntryResponse struct {
+	Seq           uint64                 `json:"seq"`
+	ActorID       domain.UserID          `json:"actor_id"`
+	Action        domain.AuditAction     `json:"action"`
+	EntityType    domain.AuditEntityType `json:"entity_type"`
+	EntityID      string                 `json:"entity_id"`
+	TargetUserIDs []domain.UserID        `json:"target_user_ids"`
+	BeforeStatus  string                 `json:"before_status,omitempty"`
+	AfterStatus   string                 `json:"after_status,omitempty"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+}
+
+func newAuditEntryResponse(entry *domain.AuditEntry) auditEntryResponse {
+	targets := entry.TargetUserIDs
+	if targets == nil {
+		targets = []domain.UserID{}
+	}
+	return auditEntryResponse{
+		Seq:           entry.Seq,
+		ActorID:       entry.ActorID,
+		Action:        entry.Action,
+		EntityType:    entry.EntityType,
+		EntityID:      entry.EntityID,
+		TargetUserIDs: targets,
+		BeforeStatus:  entry.BeforeStatus,
+		AfterStatus:   entry.AfterStatus,
+		OccurredAt:    entry.OccurredAt,
+	}
+}
+
+// List handles GET /admin/audit-log?user_id=|entity_id=&limit=
+// Exactly one of user_id and entity_id is required. Entries are returned newest first.
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	userID := values.Get("user_id")
+	entityID := values.Get("entity_id")
+	if (userID == "") == (entityID == "") {
+		writeError(w, apperrors.ValidationError("user_id", "exactly one of user_id and entity_id is required"))
+		return
+	}
+
+	var limit int
+	if v := values.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			writeError(w, apperrors.ValidationError("limit", "must be a positive integer"))
+			return
+		}
+	}
+
+	var (
+		entries []*domain.AuditEntry
+		err     error
+	)
+	if userID != "" {
+		entries, err = h.auditUsecase.ListByUser(r.Context(), domain.UserID(userID), limit)
+	} else {
+		entries, err = h.auditUsecase.ListByEntity(r.Context(), entityID, limit)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := make([]auditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, newAuditEntryResponse(entry))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
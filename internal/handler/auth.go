@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/usecase"
+)
+
+type AuthHandler struct {
+	authUsecase usecase.AuthUsecase
+}
+
+func NewAuthHandler(authUsecase usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{
+		authUsecase: authUsecase,
+	}
+}
+
+// loginRequest is the request body for logging in
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// sessionResponse is the JSON representation of an issued session
+type sessionResponse struct {
+	Token     string        `json:"token"`
+	UserID    domain.UserID `json:"user_id"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+// Login handles POST /sessions
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	token, session, err := h.authUsecase.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, sessionResponse{
+		Token:     token,
+		UserID:    session.UserID,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+// Logout handles DELETE /sessions/current
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(r)
+	if !ok {
+		writeError(w, errMissingBearerToken)
+		return
+	}
+
+	if err := h.authUsecase.Logout(r.Context(), token); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// errInvalidIfMatch is returned when the If-Match header is not a single strong ETag
+var errInvalidIfMatch = apperrors.BadRequestError("invalid If-Match header")
+
+// setETag sets the ETag header from the resource version
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion returns the version given in the If-Match header
+// It returns 0 when the header is absent or "*", meaning no precondition.
+func ifMatchVersion(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, errInvalidIfMatch
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
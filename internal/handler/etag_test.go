@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int
+		wantErr bool
+	}{
+		{header: "", want: 0},
+		{header: "*", want: 0},
+		{header: `"3"`, want: 3},
+		{header: ` "12" `, want: 12},
+		{header: "3", wantErr: true},
+		{header: `W/"3"`, wantErr: true},
+		{header: `"0"`, wantErr: true},
+		{header: `"-1"`, wantErr: true},
+		{header: `"abc"`, wantErr: true},
+		{header: `"1", "2"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest("PUT", "/users/me/time-zone", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+
+			got, err := ifMatchVersion(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ifMatchVersion(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ifMatchVersion(%q) = %d, want %d", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+// ETag は If-Match にそのまま指定できる形式で返す
+func TestSetETag(t *testing.T) {
+	w := httptest.NewRecorder()
+	setETag(w, 7)
+
+	r := httptest.NewRequest("PUT", "/users/me/time-zone", nil)
+	r.Header.Set("If-Match", w.Header().Get("ETag"))
+	if got, err := ifMatchVersion(r); err != nil || got != 7 {
+		t.Errorf("ifMatchVersion(%q) = %d, %v, want 7", w.Header().Get("ETag"), got, err)
+	}
+}
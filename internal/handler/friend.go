@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/usecase"
+)
+
+type FriendHandler struct {
+	userUsecase usecase.UserUsecase
+}
+
+func NewFriendHandler(userUsecase usecase.UserUsecase) *FriendHandler {
+	return &FriendHandler{
+		userUsecase: userUsecase,
+	}
+}
+
+// friendApplyRequest is the request body for sending a friend request
+type friendApplyRequest struct {
+	TargetUserID domain.UserID `json:"target_user_id"`
+}
+
+// blockRequest is the request body for blocking a user
+type blockRequest struct {
+	UserID domain.UserID `json:"user_id"`
+}
+
+// friendResponse is the JSON representation of a related user
+type friendResponse struct {
+	ID       domain.UserID            `json:"id"`
+	Username string                   `json:"username"`
+	Email    string                   `json:"email"`
+	Status   domain.RelatedUserStatus `json:"status"`
+}
+
+// friendReactionResponse is the result of approving or rejecting a friend request
+type friendReactionResponse struct {
+	UserID domain.UserID            `json:"user_id"`
+	Status domain.RelatedUserStatus `json:"status"`
+}
+
+func newFriendResponse(ru domain.RelatedUser) friendResponse {
+	return friendResponse{
+		ID:       ru.ID,
+		Username: ru.Username,
+		Email:    ru.Email,
+		Status:   ru.Status,
+	}
+}
+
+// Apply handles POST /friends
+func (h *FriendHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	var req friendApplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	userID := currentUserID(r)
+	if err := h.userUsecase.ApplyFriend(r.Context(), userID, req.TargetUserID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Approve handles POST /friend-requests/{fromUserID}/approve
+func (h *FriendHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.react(w, r, true)
+}
+
+// Reject handles POST /friend-requests/{fromUserID}/reject
+func (h *FriendHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.react(w, r, false)
+}
+
+func (h *FriendHandler) react(w http.ResponseWriter, r *http.Request, approve bool) {
+	userID := currentUserID(r)
+	fromUserID := domain.UserID(r.PathValue("fromUserID"))
+
+	status, err := h.userUsecase.ReactFriendApply(r.Context(), userID, fromUserID, approve)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, friendReactionResponse{
+		UserID: fromUserID,
+		Status: status,
+	})
+}
+
+// Block handles POST /blocks
+func (h *FriendHandler) Block(w http.ResponseWriter, r *http.Request) {
+	var req blockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	userID := currentUserID(r)
+	if err := h.userUsecase.BlockFriend(r.Context(), userID, req.UserID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Cancel handles POST /friend-requests/{toUserID}/cancel
+func (h *FriendHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	toUserID := domain.UserID(r.PathValue("toUserID"))
+
+	if err := h.userUsecase.CancelFriendRequest(r.Context(), userID, toUserID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Unfriend handles DELETE /friends/{friendID}
+func (h *FriendHandler) Unfriend(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	friendID := domain.UserID(r.PathValue("friendID"))
+
+	if err := h.userUsecase.Unfriend(r.Context(), userID, friendID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Unblock handles DELETE /blocks/{userID}
+func (h *FriendHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	blockedUserID := domain.UserID(r.PathValue("userID"))
+
+	if err := h.userUsecase.UnblockUser(r.Context(), userID, blockedUserID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBlocked handles GET /blocks
+func (h *FriendHandler) ListBlocked(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+
+	blocked, err := h.userUsecase.ListBlockedUsers(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := make([]friendResponse, 0, len(blocked))
+	for _, b := range blocked {
+		res = append(res, newFriendResponse(b))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// ListRequests handles GET /friend-requests
+func (h *FriendHandler) ListRequests(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+
+	requests, err := h.userUsecase.ListFriendRequests(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := make([]friendResponse, 0, len(requests))
+	for _, req := range requests {
+		res = append(res, newFriendResponse(req))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// List handles GET /friends
+func (h *FriendHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+
+	friends, err := h.userUsecase.ListFriends(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := make([]friendResponse, 0, len(friends))
+	for _, f := range friends {
+		res = append(res, newFriendResponse(f))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
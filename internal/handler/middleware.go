@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/usecase"
+)
+
+// errMissingBearerToken is returned when the Authorization header carries no bearer token
+var errMissingBearerToken = apperrors.AuthenticationError("missing bearer token")
+
+// errAdminRequired is returned when an authenticated user who is not an administrator calls an admin endpoint
+var errAdminRequired = apperrors.AuthorizationError("access admin endpoints")
+
+type contextKey int
+
+const userIDContextKey contextKey = iota
+
+// AuthMiddleware resolves the caller from the bearer token
+type AuthMiddleware struct {
+	authUsecase usecase.AuthUsecase
+	adminIDs    map[domain.UserID]bool
+}
+
+// NewAuthMiddleware creates the middleware. adminIDs are the users allowed through RequireAdmin.
+func NewAuthMiddleware(authUsecase usecase.AuthUsecase, adminIDs ...domain.UserID) *AuthMiddleware {
+	admins := make(map[domain.UserID]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+	return &AuthMiddleware{
+		authUsecase: authUsecase,
+		adminIDs:    admins,
+	}
+}
+
+// RequireAuth rejects requests without a valid session and stores the caller's ID in the request context
+func (m *AuthMiddleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, errMissingBearerToken)
+			return
+		}
+
+		userID, err := m.authUsecase.Authenticate(r.Context(), token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeError(w, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// RequireAdmin is RequireAuth restricted to the configured administrators
+func (m *AuthMiddleware) RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return m.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
+		if !m.adminIDs[currentUserID(r)] {
+			writeError(w, errAdminRequired)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// currentUserID returns the authenticated user's ID stored by RequireAuth
+func currentUserID(r *http.Request) domain.UserID {
+	userID, _ := r.Context().Value(userIDContextKey).(domain.UserID)
+	return userID
+}
+
+// bearerToken extracts the token from the Authorization header
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
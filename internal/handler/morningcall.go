@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/usecase"
+)
+
+type MorningCallHandler struct {
+	morningCallUsecase usecase.MorningCallUsecase
+}
+
+func NewMorningCallHandler(morningCallUsecase usecase.MorningCallUsecase) *MorningCallHandler {
+	return &MorningCallHandler{
+		morningCallUsecase: morningCallUsecase,
+	}
+}
+
+// morningCallRequest is the request body for creating or updating a morning call
+type morningCallRequest struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// morningCallResponse is the JSON representation of a morning call
+type morningCallResponse struct {
+	ID         domain.MorningCallID     `json:"id"`
+	SenderID   domain.UserID            `json:"sender_id"`
+	ReceiverID domain.UserID            `json:"receiver_id"`
+	Time       time.Time                `json:"time"`
+	Message    string                   `json:"message"`
+	Status     domain.MorningCallStatus `json:"status"`
+
+	DeliveredAt    time.Time `json:"delivered_at,omitzero"`
+	AckDeadline    time.Time `json:"ack_deadline,omitzero"`
+	AcknowledgedAt time.Time `json:"acknowledged_at,omitzero"`
+
+	SnoozeCount    int       `json:"snooze_count"`
+	MaxSnoozeCount int       `json:"max_snooze_count"`
+	SnoozedUntil   time.Time `json:"snoozed_until,omitzero"`
+
+	DeletedAt time.Time `json:"deleted_at,omitzero"`
+
+	Version int `json:"version"`
+}
+
+// snoozeRequest is the request body for snoozing a morning call
+type snoozeRequest struct {
+	Minutes int `json:"minutes"`
+}
+
+func newMorningCallResponse(mc *domain.MorningCall) morningCallResponse {
+	return morningCallResponse{
+		ID:         mc.ID,
+		SenderID:   mc.SenderID,
+		ReceiverID: mc.ReceiverID,
+		Time:       mc.Time,
+		Message:    mc.Message,
+		Status:     mc.Status,
+
+		DeliveredAt:    mc.DeliveredAt,
+		AckDeadline:    mc.AckDeadline,
+		AcknowledgedAt: mc.AcknowledgedAt,
+
+		SnoozeCount:    mc.SnoozeCount,
+		MaxSnoozeCount: domain.MaxSnoozeCount,
+		SnoozedUntil:   mc.SnoozedUntil,
+
+		DeletedAt: mc.DeletedAt,
+
+		Version: mc.Version,
+	}
+}
+
+// writeMorningCall writes a single morning call with its version as the ETag
+func writeMorningCall(w http.ResponseWriter, status int, mc *domain.MorningCall) {
+	setETag(w, mc.Version)
+	writeJSON(w, status, newMorningCallResponse(mc))
+}
+
+// Create handles POST /friends/{friendID}/morning-calls
+func (h *MorningCallHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req morningCallRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	userID := currentUserID(r)
+	friendID := domain.UserID(r.PathValue("friendID"))
+	morningCall := &domain.MorningCall{
+		Time:    req.Time,
+		Message: req.Message,
+	}
+
+	if err := h.morningCallUsecase.SaveFriendMorningCall(r.Context(), userID, friendID, morningCall); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMorningCall(w, http.StatusCreated, morningCall)
+}
+
+// Get handles GET /friends/{friendID}/morning-calls/{morningCallID}
+func (h *MorningCallHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	friendID := domain.UserID(r.PathValue("friendID"))
+	morningCallID := domain.MorningCallID(r.PathValue("morningCallID"))
+
+	morningCall, err := h.morningCallUsecase.GetFriendMorningCall(r.Context(), userID, friendID, morningCallID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMorningCall(w, http.StatusOK, morningCall)
+}
+
+// List handles GET /morning-calls
+//
+// Query parameters (all optional):
+//
+//	direction  sent or received
+//	status     comma-separated statuses, e.g. scheduled,snoozed; deleted calls are listed only when requested
+//	from, to   RFC 3339 range of the scheduled time, from inclusive and to exclusive
+//	friend_id  only calls exchanged with this user
+//	order      asc (default) or desc by scheduled time
+//	limit      page size, 1-100 (default 50)
+//	cursor     the X-Next-Cursor value of the previous page
+//
+// The X-Next-Cursor response header is set when there are more results.
+func (h *MorningCallHandler) List(w http.ResponseWriter, r *http.Request) {
+	query, err := parseMorningCallQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	userID := currentUserID(r)
+	cursor := r.URL.Query().Get("cursor")
+
+	morningCalls, next, err := h.morningCallUsecase.ListMorningCalls(r.Context(), userID, query, cursor)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := make([]morningCallResponse, 0, len(morningCalls))
+	for _, mc := range morningCalls {
+		res = append(res, newMorningCallResponse(mc))
+	}
+
+	if next != "" {
+		w.Header().Set("X-Next-Cursor", next)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// parseMorningCallQuery builds the list query from the URL query parameters
+func parseMorningCallQuery(r *http.Request) (domain.MorningCallQuery, error) {
+	values := r.URL.Query()
+	query := domain.MorningCallQuery{
+		Direction: domain.MorningCallDirection(values.Get("direction")),
+		FriendID:  domain.UserID(values.Get("friend_id")),
+	}
+
+	for _, v := range values["status"] {
+		for _, status := range strings.Split(v, ",") {
+			if status != "" {
+				query.Statuses = append(query.Statuses, domain.MorningCallStatus(status))
+			}
+		}
+	}
+
+	var err error
+	if query.From, err = parseTimeQuery(r, "from", time.Time{}); err != nil {
+		return query, err
+	}
+	if query.To, err = parseTimeQuery(r, "to", time.Time{}); err != nil {
+		return query, err
+	}
+
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, apperrors.ValidationError("order", "must be asc or desc")
+	}
+
+	if v := values.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil || query.Limit <= 0 {
+			return query, apperrors.ValidationError("limit", "must be a positive integer")
+		}
+	}
+
+	return query, nil
+}
+
+// Update handles PUT /morning-calls/{morningCallID}
+// An If-Match header makes the update conditional on the version.
+func (h *MorningCallHandler) Update(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req morningCallRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	userID := currentUserID(r)
+	morningCall := &domain.MorningCall{
+		ID:      domain.MorningCallID(r.PathValue("morningCallID")),
+		Time:    req.Time,
+		Message: req.Message,
+		Version: version,
+	}
+
+	if err := h.morningCallUsecase.UpdateMorningCall(r.Context(), userID, morningCall); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMorningCall(w, http.StatusOK, morningCall)
+}
+
+// Delete handles DELETE /morning-calls/{morningCallID}
+// An If-Match header makes the deletion conditional on the version.
+func (h *MorningCallHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	userID := currentUserID(r)
+	morningCallID := domain.MorningCallID(r.PathValue("morningCallID"))
+
+	if err := h.morningCallUsecase.DeleteMorningCall(r.Context(), userID, morningCallID, version); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Restore handles POST /morning-calls/{morningCallID}/restore
+func (h *MorningCallHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	morningCallID := domain.MorningCallID(r.PathValue("morningCallID"))
+
+	morningCall, err := h.morningCallUsecase.RestoreMorningCall(r.Context(), userID, morningCallID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMorningCall(w, http.StatusOK, morningCall)
+}
+
+// Acknowledge handles POST /morning-calls/{morningCallID}/acknowledge
+func (h *MorningCallHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	morningCallID := domain.MorningCallID(r.PathValue("morningCallID"))
+
+	morningCall, err := h.morningCallUsecase.AcknowledgeMorningCall(r.Context(), userID, morningCallID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMorningCall(w, http.StatusOK, morningCall)
+}
+
+// Snooze handles POST /morning-calls/{morningCallID}/snooze
+func (h *MorningCallHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	var req snoozeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	userID := currentUserID(r)
+	morningCallID := domain.MorningCallID(r.PathValue("morningCallID"))
+	duration := time.Duration(req.Minutes) * time.Minute
+
+	morningCall, err := h.morningCallUsecase.SnoozeMorningCall(r.Context(), userID, morningCallID, duration)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeMorningCall(w, http.StatusOK, morningCall)
+}
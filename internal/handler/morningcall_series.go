@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/usecase"
+)
+
+// defaultOccurrenceWindow is the range listed when "to" is omitted
+const defaultOccurrenceWindow = 30 * 24 * time.Hour
+
+type MorningCallSeriesHandler struct {
+	seriesUsecase usecase.MorningCallSeriesUsecase
+}
+
+func NewMorningCallSeriesHandler(seriesUsecase usecase.MorningCallSeriesUsecase) *MorningCallSeriesHandler {
+	return &MorningCallSeriesHandler{
+		seriesUsecase: seriesUsecase,
+	}
+}
+
+// morningCallSeriesRequest is the request body for creating a series or changing it from an occurrence
+type morningCallSeriesRequest struct {
+	Start   time.Time `json:"start"`
+	RRule   string    `json:"rrule"`
+	Message string    `json:"message"`
+}
+
+// occurrenceRequest is the request body for operations on a single occurrence onwards
+type occurrenceRequest struct {
+	Occurrence time.Time `json:"occurrence"`
+}
+
+// updateFollowingRequest is the request body for editing "this and following" occurrences
+type updateFollowingRequest struct {
+	Occurrence time.Time `json:"occurrence"`
+	morningCallSeriesRequest
+}
+
+// morningCallSeriesResponse is the JSON representation of a morning call series
+type morningCallSeriesResponse struct {
+	ID         domain.MorningCallSeriesID     `json:"id"`
+	SenderID   domain.UserID                  `json:"sender_id"`
+	ReceiverID domain.UserID                  `json:"receiver_id"`
+	Start      time.Time                      `json:"start"`
+	RRule      string                         `json:"rrule"`
+	Message    string                         `json:"message"`
+	Status     domain.MorningCallSeriesStatus `json:"status"`
+	Exceptions []time.Time                    `json:"exceptions"`
+}
+
+func newMorningCallSeriesResponse(series *domain.MorningCallSeries) morningCallSeriesResponse {
+	exceptions := series.Exceptions
+	if exceptions == nil {
+		exceptions = []time.Time{}
+	}
+	return morningCallSeriesResponse{
+		ID:         series.ID,
+		SenderID:   series.SenderID,
+		ReceiverID: series.ReceiverID,
+		Start:      series.Start,
+		RRule:      series.Rule.String(),
+		Message:    series.Message,
+		Status:     series.Status,
+		Exceptions: exceptions,
+	}
+}
+
+// toSeries converts the request to a domain series. An empty rrule is allowed
+// only when optional is true, leaving the rule to be inherited.
+func (req morningCallSeriesRequest) toSeries(optional bool) (*domain.MorningCallSeries, error) {
+	series := &domain.MorningCallSeries{
+		Start:   req.Start,
+		Message: req.Message,
+	}
+	if req.RRule == "" && optional {
+		return series, nil
+	}
+
+	rule, err := domain.ParseRecurrenceRule(req.RRule)
+	if err != nil {
+		return nil, apperrors.ValidationError("rrule", err.Error())
+	}
+	series.Rule = rule
+	return series, nil
+}
+
+// Create handles POST /friends/{friendID}/morning-call-series
+func (h *MorningCallSeriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req morningCallSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	series, err := req.toSeries(false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	userID := currentUserID(r)
+	friendID := domain.UserID(r.PathValue("friendID"))
+
+	if err := h.seriesUsecase.CreateSeries(r.Context(), userID, friendID, series); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newMorningCallSeriesResponse(series))
+}
+
+// Get handles GET /morning-call-series/{seriesID}
+func (h *MorningCallSeriesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+	seriesID := domain.MorningCallSeriesID(r.PathValue("seriesID"))
+
+	series, err := h.seriesUsecase.GetSeries(r.Context(), userID, seriesID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newMorningCallSeriesResponse(series))
+}
+
+// List handles GET /morning-call-series
+func (h *MorningCallSeriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := currentUserID(r)
+
+	seriesList, err := h.seriesUsecase.ListSeries(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := make([]morningCallSeriesResponse, 0, len(seriesList))
+	for _, series := range seriesList {
+		res = append(res, newMorningCallSeriesResponse(series))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// Occurrences handles GET /morning-call-series/{seriesID}/occurrences?from=&to=
+// from defaults to now and to defaults to 30 days after from.
+func (h *MorningCallSeriesHandler) Occurrences(w http.ResponseWriter, r *http.Request) {
+	from, err := parseTimeQuery(r, "from", time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	to, err := parseTimeQuery(r, "to", from.Add(defaultOccurrenceWindow))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	userID := currentUserID(r)
+	seriesID := domain.MorningCallSeriesID(r.PathValue("seriesID"))
+
+	occurrences, err := h.seriesUsecase.ListOccurrences(r.Context(), userID, seriesID, from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if occurrences == nil {
+		occurrences = []time.Time{}
+	}
+
+	writeJSON(w, http.StatusOK, occurrences)
+}
+
+// Skip handles POST /morning-call-series/{seriesID}/exceptions
+func (h *MorningCallSeriesHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	var req occurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	userID := currentUserID(r)
+	seriesID := domain.MorningCallSeriesID(r.PathValue("seriesID"))
+
+	if err := h.seriesUsecase.SkipOccurrence(r.Context(), userID, seriesID, req.Occurrence); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateFollowing handles PUT /morning-call-series/{seriesID}/following
+// The series is split at the occurrence and the new series is returned.
+func (h *MorningCallSeriesHandler) UpdateFollowing(w http.ResponseWriter, r *http.Request) {
+	var req updateFollowingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	changes, err := req.toSeries(true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	userID := currentUserID(r)
+	seriesID := domain.MorningCallSeriesID(r.PathValue("seriesID"))
+
+	following, err := h.seriesUsecase.UpdateFollowing(r.Context(), userID, seriesID, req.Occurrence, changes)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newMorningCallSeriesResponse(following))
+}
+
+// Cancel handles POST /morning-call-series/{seriesID}/cancel
+// Without an occurrence the whole series is cancelled.
+func (h *MorningCallSeriesHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	var req occurrenceRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, errInvalidRequestBody)
+			return
+		}
+	}
+
+	userID := currentUserID(r)
+	seriesID := domain.MorningCallSeriesID(r.PathValue("seriesID"))
+
+	if err := h.seriesUsecase.CancelFollowing(r.Context(), userID, seriesID, req.Occurrence); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTimeQuery parses an RFC 3339 query parameter, returning fallback when it is absent
+func parseTimeQuery(r *http.Request, name string, fallback time.Time) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return fallback, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, apperrors.ValidationError(name, "must be an RFC 3339 timestamp")
+	}
+	return t, nil
+}
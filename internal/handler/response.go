@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// writeJSON writes v as a JSON response body with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err as an ErrorResponse with the status code mapped from its type
+func writeError(w http.ResponseWriter, err error) {
+	status := apperrors.HTTPStatusFromError(err)
+	if status >= http.StatusInternalServerError {
+		// 内部エラーの詳細はクライアントに返さない
+		log.Printf("internal error: %v", err)
+		err = apperrors.InternalError("internal server error")
+	}
+
+	writeJSON(w, status, apperrors.ToErrorResponse(err))
+}
+
+// errInvalidRequestBody is returned when the request body cannot be decoded
+var errInvalidRequestBody = apperrors.BadRequestError("invalid request body")
@@ -3,7 +3,10 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
 	"morning-call/internal/usecase"
 )
 
@@ -17,6 +20,26 @@ func NewUserHandler(userUsecase usecase.UserUsecase) *UserHandler {
 	}
 }
 
+// userResponse is the JSON representation of a user
+// Credentials are never included
+type userResponse struct {
+	ID       domain.UserID `json:"id"`
+	Username string        `json:"username"`
+	Email    string        `json:"email"`
+	TimeZone string        `json:"time_zone"`
+	Version  int           `json:"version"`
+}
+
+func newUserResponse(user *domain.User) userResponse {
+	return userResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		TimeZone: user.TimeZone,
+		Version:  user.Version,
+	}
+}
+
 func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Invalid request method", http.StatusMethodNotAllowed)
@@ -26,20 +49,228 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Username string
 		Email    string
+		Password string
+		TimeZone string `json:"time_zone"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	user, err := h.userUsecase.Register(r.Context(), req.Username, req.Email, req.Password, req.TimeZone)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusCreated, newUserResponse(user))
+}
+
+// Me handles GET /me
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userUsecase.GetUser(r.Context(), currentUserID(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+// notificationSettingsBody is the request and response body for notification settings
+type notificationSettingsBody struct {
+	Channels   []domain.NotificationChannel `json:"channels"`
+	WebhookURL string                       `json:"webhook_url,omitempty"`
+}
+
+func newNotificationSettingsBody(settings domain.NotificationSettings) notificationSettingsBody {
+	return notificationSettingsBody{
+		Channels:   settings.Channels,
+		WebhookURL: settings.WebhookURL,
+	}
+}
+
+// GetNotificationSettings handles GET /notification-settings
+// The ETag is the user's version, shared with PUT /time-zone.
+func (h *UserHandler) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userUsecase.GetUser(r.Context(), currentUserID(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusOK, newNotificationSettingsBody(user.Notification))
+}
+
+// UpdateNotificationSettings handles PUT /notification-settings
+func (h *UserHandler) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req notificationSettingsBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	settings := domain.NotificationSettings{
+		Channels:   req.Channels,
+		WebhookURL: req.WebhookURL,
+	}
+	user, err := h.userUsecase.UpdateNotificationSettings(r.Context(), currentUserID(r), settings, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusOK, newNotificationSettingsBody(user.Notification))
+}
+
+// timeZoneRequest is the request body for changing the user's time zone
+type timeZoneRequest struct {
+	TimeZone string `json:"time_zone"`
+}
+
+// UpdateTimeZone handles PUT /time-zone
+func (h *UserHandler) UpdateTimeZone(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req timeZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	user, err := h.userUsecase.UpdateTimeZone(r.Context(), currentUserID(r), req.TimeZone, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+// wakeWindowBody is the JSON representation of a wake window as "HH:MM" times in the user's time zone
+type wakeWindowBody struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// vacationBody is the JSON representation of a vacation pause
+type vacationBody struct {
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+}
+
+// receiverPreferencesBody is the request and response body for receiver preferences
+// Omitting wake_window or vacation clears it
+type receiverPreferencesBody struct {
+	WakeWindow     *wakeWindowBody `json:"wake_window,omitempty"`
+	DailyLimit     int             `json:"daily_limit"`
+	AllowedCallers []domain.UserID `json:"allowed_callers"`
+	DeniedCallers  []domain.UserID `json:"denied_callers"`
+	Vacation       *vacationBody   `json:"vacation,omitempty"`
+	// MinimumGapMinutes is the minimum interval between morning calls to the user
+	MinimumGapMinutes int `json:"minimum_gap_minutes"`
+}
+
+func newReceiverPreferencesBody(prefs domain.ReceiverPreferences) receiverPreferencesBody {
+	body := receiverPreferencesBody{
+		DailyLimit:        prefs.DailyLimit,
+		AllowedCallers:    append([]domain.UserID{}, prefs.AllowedCallers...),
+		DeniedCallers:     append([]domain.UserID{}, prefs.DeniedCallers...),
+		MinimumGapMinutes: int(prefs.MinimumGap / time.Minute),
+	}
+	if !prefs.WakeWindow.IsZero() {
+		body.WakeWindow = &wakeWindowBody{
+			Start: prefs.WakeWindow.Start.String(),
+			End:   prefs.WakeWindow.End.String(),
+		}
+	}
+	if !prefs.VacationFrom.IsZero() {
+		body.Vacation = &vacationBody{
+			From:  prefs.VacationFrom,
+			Until: prefs.VacationUntil,
+		}
+	}
+	return body
+}
+
+// toDomain converts the body, reporting malformed wake window times as validation errors
+func (b receiverPreferencesBody) toDomain() (domain.ReceiverPreferences, error) {
+	prefs := domain.ReceiverPreferences{
+		DailyLimit:     b.DailyLimit,
+		AllowedCallers: b.AllowedCallers,
+		DeniedCallers:  b.DeniedCallers,
+		MinimumGap:     time.Duration(b.MinimumGapMinutes) * time.Minute,
+	}
+	if b.WakeWindow != nil {
+		start, okStart := domain.ParseTimeOfDay(b.WakeWindow.Start)
+		end, okEnd := domain.ParseTimeOfDay(b.WakeWindow.End)
+		if !okStart || !okEnd {
+			return prefs, apperrors.ValidationError("wake_window", domain.NGReasonInvalidWakeWindow.String())
+		}
+		prefs.WakeWindow = domain.WakeWindow{Start: start, End: end}
+	}
+	if b.Vacation != nil {
+		prefs.VacationFrom = b.Vacation.From
+		prefs.VacationUntil = b.Vacation.Until
+	}
+	return prefs, nil
+}
+
+// GetReceiverPreferences handles GET /preferences
+// The ETag is the user's version, shared with the other user settings.
+func (h *UserHandler) GetReceiverPreferences(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userUsecase.GetUser(r.Context(), currentUserID(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusOK, newReceiverPreferencesBody(user.Preferences))
+}
+
+// UpdateReceiverPreferences handles PUT /preferences
+func (h *UserHandler) UpdateReceiverPreferences(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req receiverPreferencesBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	prefs, err := req.toDomain()
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
-	user, err := h.userUsecase.Register(r.Context(), req.Username, req.Email)
+	user, err := h.userUsecase.UpdateReceiverPreferences(r.Context(), currentUserID(r), prefs, version)
 	if err != nil {
-		http.Error(w, "Failed to register user", http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(user)
+	setETag(w, user.Version)
+	writeJSON(w, http.StatusOK, newReceiverPreferencesBody(user.Preferences))
 }
@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/usecase"
+)
+
+// logNotifier はモーニングコールを構造化ログに出力する Notifier です
+type logNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier は新しい logNotifier を生成します
+func NewLogNotifier(logger *slog.Logger) usecase.Notifier {
+	return &logNotifier{
+		logger: logger,
+	}
+}
+
+func (n *logNotifier) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelLog
+}
+
+func (n *logNotifier) Notify(ctx context.Context, notification *usecase.Notification) (*usecase.NotificationResult, error) {
+	n.logger.InfoContext(ctx, "morning call",
+		slog.String("morning_call_id", string(notification.MorningCallID)),
+		slog.String("sender_id", string(notification.Sender.ID)),
+		slog.String("receiver_id", string(notification.Receiver.ID)),
+		slog.Time("scheduled_at", notification.ScheduledAt),
+		slog.String("message", notification.Message),
+	)
+
+	return &usecase.NotificationResult{
+		Channel:     n.Channel(),
+		DeliveredAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/usecase"
+)
+
+// SMTPConfig holds the connection settings of the outgoing mail server
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	From     string
+	Username string
+	Password string
+}
+
+// smtpNotifier は受信者のメールアドレスへメールを送信する Notifier です
+type smtpNotifier struct {
+	config SMTPConfig
+	dialer *net.Dialer
+}
+
+// NewSMTPNotifier は新しい smtpNotifier を生成します
+func NewSMTPNotifier(config SMTPConfig) usecase.Notifier {
+	return &smtpNotifier{
+		config: config,
+		dialer: &net.Dialer{},
+	}
+}
+
+func (n *smtpNotifier) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelEmail
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, notification *usecase.Notification) (*usecase.NotificationResult, error) {
+	to := notification.Receiver.Email
+	if to == "" {
+		return nil, fmt.Errorf("receiver %s has no email address", notification.Receiver.ID)
+	}
+
+	// net/smtp は context を受け取らないため、接続にデッドラインを設定して打ち切る
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	conn, err := n.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host}); err != nil {
+			return nil, err
+		}
+	}
+
+	if n.config.Username != "" {
+		auth := smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return nil, err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return nil, err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(n.buildMessage(to, notification)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := client.Quit(); err != nil {
+		return nil, err
+	}
+
+	return &usecase.NotificationResult{
+		Channel:     n.Channel(),
+		DeliveredAt: time.Now(),
+		Detail:      to,
+	}, nil
+}
+
+// buildMessage はRFC 5322形式のメール本文を組み立てます
+func (n *smtpNotifier) buildMessage(to string, notification *usecase.Notification) []byte {
+	subject := fmt.Sprintf("%sさんからのモーニングコール", notification.Sender.Username)
+
+	var body strings.Builder
+	body.WriteString(notification.Message)
+	body.WriteString("\r\n\r\n")
+	body.WriteString("予定時刻: ")
+	body.WriteString(notification.ScheduledAt.Format(time.RFC3339))
+	body.WriteString("\r\n")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(body.String())
+	return buf.Bytes()
+}
@@ -0,0 +1,196 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+)
+
+// smtpStub は1通ずつメールを受け取るだけの SMTP サーバーです
+// rejectRcpt が設定されていれば RCPT TO をそのアドレスで拒否します
+type smtpStub struct {
+	listener   net.Listener
+	rejectRcpt string
+
+	mu   sync.Mutex
+	from string
+	to   []string
+	data string
+	done chan struct{}
+}
+
+func newSMTPStub(t *testing.T, rejectRcpt string) *smtpStub {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	stub := &smtpStub{listener: listener, rejectRcpt: rejectRcpt, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go stub.serve()
+	return stub
+}
+
+func (s *smtpStub) config() SMTPConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "noreply@example.com"}
+}
+
+func (s *smtpStub) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	reply := func(format string, args ...any) { tp.PrintfLine(format, args...) }
+
+	reply("220 localhost ESMTP stub")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = addressArg(arg)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RCPT":
+			to := addressArg(arg)
+			if to == s.rejectRcpt {
+				reply("550 no such user")
+				continue
+			}
+			s.mu.Lock()
+			s.to = append(s.to, to)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "DATA":
+			reply("354 end with <CRLF>.<CRLF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data = string(data)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+// addressArg は "FROM:<a@example.com>" のような引数からアドレスを取り出します
+func addressArg(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(addr, " ")
+	return strings.Trim(addr, "<>")
+}
+
+func (s *smtpStub) wait(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SMTP session did not finish")
+	}
+}
+
+func TestSMTPNotifier_SendsMail(t *testing.T) {
+	stub := newSMTPStub(t, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n := NewSMTPNotifier(stub.config())
+	result, err := n.Notify(ctx, newTestNotification(""))
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	stub.wait(t)
+
+	if result.Channel != domain.NotificationChannelEmail || result.Detail != "bob@example.com" {
+		t.Errorf("result = %+v, want email channel to bob@example.com", result)
+	}
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	if stub.from != "noreply@example.com" || len(stub.to) != 1 || stub.to[0] != "bob@example.com" {
+		t.Errorf("envelope = %s -> %v", stub.from, stub.to)
+	}
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(stub.data)))
+	if err != nil {
+		t.Fatalf("message: %v\n%s", err, stub.data)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("subject: %v", err)
+	}
+	if want := "aliceさんからのモーニングコール"; subject != want {
+		t.Errorf("Subject = %q, want %q", subject, want)
+	}
+	if got := msg.Header.Get("To"); got != "bob@example.com" {
+		t.Errorf("To = %q", got)
+	}
+
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatalf("body: %v", err)
+	}
+	if text := string(body); !strings.Contains(text, "おはよう") || !strings.Contains(text, "2026-10-19T07:00:00Z") {
+		t.Errorf("body = %q, want the message and scheduled time", text)
+	}
+}
+
+func TestSMTPNotifier_FailsWhenRecipientRejected(t *testing.T) {
+	stub := newSMTPStub(t, "bob@example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n := NewSMTPNotifier(stub.config())
+	_, err := n.Notify(ctx, newTestNotification(""))
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("Notify error = %v, want the 550 reply", err)
+	}
+}
+
+func TestSMTPNotifier_RequiresEmail(t *testing.T) {
+	notification := newTestNotification("")
+	notification.Receiver.Email = ""
+
+	n := NewSMTPNotifier(SMTPConfig{Host: "127.0.0.1", Port: 1})
+	if _, err := n.Notify(context.Background(), notification); err == nil {
+		t.Error("Notify error = nil, want an error for a receiver without an email address")
+	}
+}
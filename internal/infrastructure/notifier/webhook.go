@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/usecase"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body when a secret is configured
+const SignatureHeader = "X-Morning-Call-Signature"
+
+// ErrDisallowedAddress is returned when a webhook URL resolves to an address on the server's own network
+var ErrDisallowedAddress = errors.New("webhook destination address is not allowed")
+
+// webhookPayload is the JSON body posted to the receiver's webhook URL
+type webhookPayload struct {
+	MorningCallID domain.MorningCallID `json:"morning_call_id"`
+	SenderID      domain.UserID        `json:"sender_id"`
+	SenderName    string               `json:"sender_name"`
+	ReceiverID    domain.UserID        `json:"receiver_id"`
+	Message       string               `json:"message"`
+	ScheduledAt   time.Time            `json:"scheduled_at"`
+}
+
+// webhookNotifier は受信者が登録したURLへJSONをPOSTする Notifier です
+type webhookNotifier struct {
+	client *http.Client
+	secret []byte
+}
+
+// NewWebhookNotifier は新しい webhookNotifier を生成します
+// secret が空でない場合はリクエストボディの署名を付与します
+// timeout はリダイレクトを含む1回の配信に許容する時間です
+func NewWebhookNotifier(timeout time.Duration, secret string) usecase.Notifier {
+	return newWebhookNotifier(timeout, secret, domain.IsPublicWebhookAddr)
+}
+
+// newWebhookNotifier は接続を許可するアドレスを allow で判定する webhookNotifier を生成します
+func newWebhookNotifier(timeout time.Duration, secret string, allow func(netip.Addr) bool) *webhookNotifier {
+	// 名前解決後の接続先を検査するため、DNS の応答を差し替えられても内部ネットワークには接続しない
+	// リダイレクト先への接続も同じダイアラーを通る
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !allow(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrDisallowedAddress, address)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシ経由では接続先を検査できないため使用しない
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &webhookNotifier{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+		secret: []byte(secret),
+	}
+}
+
+func (n *webhookNotifier) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelWebhook
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, notification *usecase.Notification) (*usecase.NotificationResult, error) {
+	url := notification.Receiver.Notification.WebhookURL
+	if url == "" {
+		return nil, fmt.Errorf("receiver %s has no webhook URL", notification.Receiver.ID)
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		MorningCallID: notification.MorningCallID,
+		SenderID:      notification.Sender.ID,
+		SenderName:    notification.Sender.Username,
+		ReceiverID:    notification.Receiver.ID,
+		Message:       notification.Message,
+		ScheduledAt:   notification.ScheduledAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.secret) > 0 {
+		mac := hmac.New(sha256.New, n.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return &usecase.NotificationResult{
+		Channel:     n.Channel(),
+		DeliveredAt: time.Now(),
+		Detail:      strconv.Itoa(res.StatusCode),
+	}, nil
+}
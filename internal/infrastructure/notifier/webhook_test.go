@@ -0,0 +1,171 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/usecase"
+)
+
+// allowAnyAddr は httptest のループバックアドレスへの接続を許可します
+func allowAnyAddr(netip.Addr) bool {
+	return true
+}
+
+func newTestNotification(webhookURL string) *usecase.Notification {
+	return &usecase.Notification{
+		MorningCallID: "mc-1",
+		Sender:        &domain.User{ID: "sender", Username: "alice"},
+		Receiver: &domain.User{
+			ID:           "receiver",
+			Email:        "bob@example.com",
+			Notification: domain.NotificationSettings{WebhookURL: webhookURL},
+		},
+		Message:     "おはよう",
+		ScheduledAt: time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifier_PostsSignedPayload(t *testing.T) {
+	const secret = "s3cret"
+
+	var gotSignature string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := newWebhookNotifier(time.Second, secret, allowAnyAddr)
+	result, err := n.Notify(context.Background(), newTestNotification(server.URL))
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if result.Channel != domain.NotificationChannelWebhook || result.Detail != "204" {
+		t.Errorf("result = %+v, want webhook channel with status 204", result)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(gotBody)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); gotSignature != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, gotSignature, want)
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload.MorningCallID != "mc-1" || payload.SenderName != "alice" || payload.ReceiverID != "receiver" || payload.Message != "おはよう" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestWebhookNotifier_OmitsSignatureWithoutSecret(t *testing.T) {
+	signed := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, signed = r.Header[SignatureHeader]
+	}))
+	defer server.Close()
+
+	n := newWebhookNotifier(time.Second, "", allowAnyAddr)
+	if _, err := n.Notify(context.Background(), newTestNotification(server.URL)); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if signed {
+		t.Errorf("request has %s without a secret", SignatureHeader)
+	}
+}
+
+func TestWebhookNotifier_FailsOnNon2xx(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusInternalServerError} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			n := newWebhookNotifier(time.Second, "", allowAnyAddr)
+			result, err := n.Notify(context.Background(), newTestNotification(server.URL))
+			if err == nil {
+				t.Fatalf("Notify = %+v, want an error for status %d", result, status)
+			}
+			if !strings.Contains(err.Error(), strconv.Itoa(status)) {
+				t.Errorf("error = %v, want the response status", err)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_RefusesLoopback(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(time.Second, "")
+	_, err := n.Notify(context.Background(), newTestNotification(server.URL))
+	if !errors.Is(err, ErrDisallowedAddress) {
+		t.Errorf("Notify error = %v, want %v", err, ErrDisallowedAddress)
+	}
+	if called {
+		t.Error("webhook reached the loopback server")
+	}
+}
+
+func TestWebhookNotifier_RefusesRedirectToDisallowedAddress(t *testing.T) {
+	called := false
+	internal := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	listener, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("cannot listen on 127.0.0.2: %v", err)
+	}
+	internal.Listener.Close()
+	internal.Listener = listener
+	internal.Start()
+	defer internal.Close()
+
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer public.Close()
+
+	// 127.0.0.1 を公開アドレスに見立て、リダイレクト先の 127.0.0.2 は拒否する
+	allowPublic := func(addr netip.Addr) bool {
+		return addr == netip.MustParseAddr("127.0.0.1")
+	}
+
+	n := newWebhookNotifier(time.Second, "", allowPublic)
+	_, err = n.Notify(context.Background(), newTestNotification(public.URL))
+	if !errors.Is(err, ErrDisallowedAddress) {
+		t.Errorf("Notify error = %v, want %v", err, ErrDisallowedAddress)
+	}
+	if called {
+		t.Error("redirect reached the disallowed server")
+	}
+}
+
+func TestWebhookNotifier_RequiresURL(t *testing.T) {
+	n := newWebhookNotifier(time.Second, "", allowAnyAddr)
+	if _, err := n.Notify(context.Background(), newTestNotification("")); err == nil {
+		t.Error("Notify error = nil, want an error for a receiver without a webhook URL")
+	}
+}
@@ -0,0 +1,40 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const auditCollection = "audit_log"
+
+// fileAuditRepository は AuditRepository のファイル永続化実装です
+type fileAuditRepository struct {
+	repository.AuditRepository
+	store *Store
+}
+
+// NewFileAuditRepository は保存済みの監査ログを読み込み、fileAuditRepository を生成します
+func NewFileAuditRepository(store *Store) (repository.AuditRepository, error) {
+	inner := inmemory.NewInMemoryAuditRepository()
+	err := load(store, auditCollection, func(entry *domain.AuditEntry) error {
+		return inner.Append(context.Background(), entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileAuditRepository{AuditRepository: inner, store: store}, nil
+}
+
+func (r *fileAuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	// 通し番号はインメモリ側で採番するため、反映後に記録する変更を決める
+	return r.store.writeChanges(ctx, func(ctx context.Context) ([]change, error) {
+		if err := r.AuditRepository.Append(ctx, entry); err != nil {
+			return nil, err
+		}
+		return []change{{collection: auditCollection, id: sequenceID(entry.Seq), value: entry}}, nil
+	})
+}
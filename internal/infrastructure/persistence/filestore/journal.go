@@ -0,0 +1,137 @@
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+)
+
+type operation string
+
+const (
+	opPut    operation = "put"
+	opDelete operation = "delete"
+)
+
+// record はエンティティ1件の変更です
+type record struct {
+	Collection string          `json:"collection"`
+	Op         operation       `json:"op"`
+	ID         string          `json:"id"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// entry はジャーナルの1行で、1回の書き込み（トランザクション）の全レコードを持ちます
+// ファイル上では "<CRC32(16進8桁)> <JSON>\n" の1行として保存するため、再生時は全件反映か全件破棄のどちらかになります
+type entry struct {
+	Seq     uint64   `json:"seq"`
+	Records []record `json:"records"`
+}
+
+// appendEntry はエントリを1行で書き込み、fsync します
+func appendEntry(f *os.File, e entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%08x ", crc32.ChecksumIEEE(line))
+	buf.Write(line)
+	buf.WriteByte('\n')
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return nil
+}
+
+// readJournal はジャーナルの全エントリを読み込みます
+// 書き込み途中でクラッシュした末尾の不完全な行は切り捨て、それ以外の破損はエラーとします
+func readJournal(path string, logger *slog.Logger) ([]entry, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		entries []entry
+		offset  int64
+	)
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				// 改行で終わっていない行は書き込み途中のため切り捨てる
+				logger.Warn("truncating incomplete journal entry", "offset", offset, "bytes", len(line))
+				if err := truncate(f, offset); err != nil {
+					return nil, err
+				}
+			}
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal: %w", err)
+		}
+
+		e, err := decodeEntry(line)
+		if err != nil {
+			if _, peekErr := reader.Peek(1); errors.Is(peekErr, io.EOF) {
+				// 最終行の破損も書き込み途中のクラッシュとして扱う
+				logger.Warn("truncating corrupt journal entry", "offset", offset, "error", err)
+				if err := truncate(f, offset); err != nil {
+					return nil, err
+				}
+				return entries, nil
+			}
+			return nil, fmt.Errorf("journal is corrupt at offset %d: %w", offset, err)
+		}
+
+		entries = append(entries, e)
+		offset += int64(len(line))
+	}
+}
+
+func decodeEntry(line []byte) (entry, error) {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	sum, payload, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return entry{}, errors.New("missing checksum")
+	}
+
+	want, err := strconv.ParseUint(string(sum), 16, 32)
+	if err != nil {
+		return entry{}, fmt.Errorf("invalid checksum: %w", err)
+	}
+	if crc32.ChecksumIEEE(payload) != uint32(want) {
+		return entry{}, errors.New("checksum mismatch")
+	}
+
+	var e entry
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return entry{}, err
+	}
+	return e, nil
+}
+
+func truncate(f *os.File, size int64) error {
+	if err := f.Truncate(size); err != nil {
+		return fmt.Errorf("failed to truncate journal: %w", err)
+	}
+	return f.Sync()
+}
@@ -0,0 +1,178 @@
+package filestore
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// writeTestJournal は seq 1..n のエントリを書き込んだジャーナルのパスを返します
+func writeTestJournal(t *testing.T, n int) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), journalFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	defer f.Close()
+
+	for i := 1; i <= n; i++ {
+		e := entry{Seq: uint64(i), Records: []record{{Collection: "users", Op: opPut, ID: "u", Data: json.RawMessage(`{"n":1}`)}}}
+		if err := appendEntry(f, e); err != nil {
+			t.Fatalf("appendEntry: %v", err)
+		}
+	}
+	return path
+}
+
+func appendRaw(t *testing.T, path, data string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	return info.Size()
+}
+
+func TestReadJournal(t *testing.T) {
+	path := writeTestJournal(t, 3)
+
+	entries, err := readJournal(path, discardLogger)
+	if err != nil {
+		t.Fatalf("readJournal: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("entries = %d, want 3", len(entries))
+	}
+	for i, e := range entries {
+		if e.Seq != uint64(i+1) || len(e.Records) != 1 || e.Records[0].Op != opPut {
+			t.Errorf("entry %d = %+v", i, e)
+		}
+	}
+}
+
+func TestReadJournal_MissingFile(t *testing.T) {
+	entries, err := readJournal(filepath.Join(t.TempDir(), journalFileName), discardLogger)
+	if err != nil || entries != nil {
+		t.Errorf("readJournal = %v, %v, want nothing", entries, err)
+	}
+}
+
+// 書き込み途中でクラッシュした末尾の行は切り捨て、以降の追記が正しく読めるようにする
+func TestReadJournal_TruncatesTornTail(t *testing.T) {
+	tests := []struct {
+		name string
+		tail string
+	}{
+		{"without newline", `1234abcd {"seq":3,"rec`},
+		{"checksum mismatch", "00000000 {\"seq\":3,\"records\":[]}\n"},
+		{"missing checksum", "{\"seq\":3}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestJournal(t, 2)
+			size := fileSize(t, path)
+			appendRaw(t, path, tt.tail)
+
+			entries, err := readJournal(path, discardLogger)
+			if err != nil {
+				t.Fatalf("readJournal: %v", err)
+			}
+			if len(entries) != 2 {
+				t.Errorf("entries = %d, want 2", len(entries))
+			}
+			if got := fileSize(t, path); got != size {
+				t.Errorf("journal size = %d, want it truncated to %d", got, size)
+			}
+		})
+	}
+}
+
+// 末尾以外の破損は切り捨てずにエラーとする
+func TestReadJournal_RejectsCorruptionBeforeTail(t *testing.T) {
+	path := writeTestJournal(t, 1)
+	appendRaw(t, path, "00000000 {\"seq\":2,\"records\":[]}\n")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if err := appendEntry(f, entry{Seq: 3}); err != nil {
+		t.Fatalf("appendEntry: %v", err)
+	}
+	f.Close()
+	size := fileSize(t, path)
+
+	if _, err := readJournal(path, discardLogger); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("readJournal error = %v, want a corrupt journal error", err)
+	}
+	if got := fileSize(t, path); got != size {
+		t.Errorf("journal size = %d, want it left at %d", got, size)
+	}
+}
+
+func TestDecodeEntry_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"{\"seq\":1}\n",
+		"zzzzzzzz {\"seq\":1}\n",
+		"00000000 {\"seq\":1}\n",
+		"8d6f0bd3 {\"seq\":\n",
+	}
+
+	for _, line := range tests {
+		t.Run(line, func(t *testing.T) {
+			if _, err := decodeEntry([]byte(line)); err == nil {
+				t.Errorf("decodeEntry(%q) error = nil", line)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, snapshotFileName)
+
+	for _, content := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(content)); err != nil {
+			t.Fatalf("writeFileAtomic: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if string(data) != content {
+			t.Errorf("content = %q, want %q", data, content)
+		}
+	}
+
+	// 一時ファイルは残さない
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("files = %v, want only the snapshot", files)
+	}
+}
@@ -0,0 +1,52 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const morningCallsCollection = "morning_calls"
+
+// fileMorningCallRepository は MorningCallRepository のファイル永続化実装です
+type fileMorningCallRepository struct {
+	repository.MorningCallRepository
+	store *Store
+}
+
+// NewFileMorningCallRepository は保存済みのモーニングコールを読み込み、fileMorningCallRepository を生成します
+func NewFileMorningCallRepository(store *Store) (repository.MorningCallRepository, error) {
+	inner := inmemory.NewInMemoryMorningCallRepository()
+	err := load(store, morningCallsCollection, func(morningCall *domain.MorningCall) error {
+		return inner.Save(context.Background(), morningCall)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileMorningCallRepository{MorningCallRepository: inner, store: store}, nil
+}
+
+func (r *fileMorningCallRepository) Save(ctx context.Context, morningCall *domain.MorningCall) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.MorningCallRepository.Save(ctx, morningCall)
+	}, putMorningCall(morningCall))
+}
+
+func (r *fileMorningCallRepository) Update(ctx context.Context, morningCall *domain.MorningCall) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.MorningCallRepository.Update(ctx, morningCall)
+	}, putMorningCall(morningCall))
+}
+
+func (r *fileMorningCallRepository) Delete(ctx context.Context, id domain.MorningCallID) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.MorningCallRepository.Delete(ctx, id)
+	}, change{collection: morningCallsCollection, id: string(id)})
+}
+
+func putMorningCall(morningCall *domain.MorningCall) change {
+	return change{collection: morningCallsCollection, id: string(morningCall.ID), value: morningCall}
+}
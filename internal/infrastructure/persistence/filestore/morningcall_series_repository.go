@@ -0,0 +1,46 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const morningCallSeriesCollection = "morning_call_series"
+
+// fileMorningCallSeriesRepository は MorningCallSeriesRepository のファイル永続化実装です
+type fileMorningCallSeriesRepository struct {
+	repository.MorningCallSeriesRepository
+	store *Store
+}
+
+// NewFileMorningCallSeriesRepository は保存済みの繰り返し設定を読み込み、fileMorningCallSeriesRepository を生成します
+func NewFileMorningCallSeriesRepository(store *Store) (repository.MorningCallSeriesRepository, error) {
+	inner := inmemory.NewInMemoryMorningCallSeriesRepository()
+	err := load(store, morningCallSeriesCollection, func(series *domain.MorningCallSeries) error {
+		return inner.Save(context.Background(), series)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileMorningCallSeriesRepository{MorningCallSeriesRepository: inner, store: store}, nil
+}
+
+func (r *fileMorningCallSeriesRepository) Save(ctx context.Context, series *domain.MorningCallSeries) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.MorningCallSeriesRepository.Save(ctx, series)
+	}, putMorningCallSeries(series))
+}
+
+func (r *fileMorningCallSeriesRepository) Update(ctx context.Context, series *domain.MorningCallSeries) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.MorningCallSeriesRepository.Update(ctx, series)
+	}, putMorningCallSeries(series))
+}
+
+func putMorningCallSeries(series *domain.MorningCallSeries) change {
+	return change{collection: morningCallSeriesCollection, id: string(series.ID), value: series}
+}
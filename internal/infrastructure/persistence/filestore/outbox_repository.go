@@ -0,0 +1,72 @@
+package filestore
+
+import (
+	"context"
+	"fmt"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const (
+	outboxCollection      = "outbox"
+	checkpointsCollection = "outbox_checkpoints"
+)
+
+// fileOutboxRepository は OutboxRepository のファイル永続化実装です
+type fileOutboxRepository struct {
+	repository.OutboxRepository
+	store *Store
+}
+
+// outboxCheckpoint は購読者ごとの処理済みオフセットの保存形式です
+type outboxCheckpoint struct {
+	Subscriber string `json:"subscriber"`
+	Offset     uint64 `json:"offset"`
+}
+
+// NewFileOutboxRepository は保存済みのイベントとチェックポイントを読み込み、fileOutboxRepository を生成します
+func NewFileOutboxRepository(store *Store) (repository.OutboxRepository, error) {
+	inner := inmemory.NewInMemoryOutboxRepository()
+	err := load(store, outboxCollection, func(record *domain.EventRecord) error {
+		return inner.Append(context.Background(), record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = load(store, checkpointsCollection, func(checkpoint *outboxCheckpoint) error {
+		return inner.SaveCheckpoint(context.Background(), checkpoint.Subscriber, checkpoint.Offset)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileOutboxRepository{OutboxRepository: inner, store: store}, nil
+}
+
+func (r *fileOutboxRepository) Append(ctx context.Context, records ...*domain.EventRecord) error {
+	// オフセットはインメモリ側で採番するため、反映後に記録する変更を決める
+	return r.store.writeChanges(ctx, func(ctx context.Context) ([]change, error) {
+		if err := r.OutboxRepository.Append(ctx, records...); err != nil {
+			return nil, err
+		}
+		changes := make([]change, 0, len(records))
+		for _, record := range records {
+			changes = append(changes, change{collection: outboxCollection, id: sequenceID(record.Offset), value: record})
+		}
+		return changes, nil
+	})
+}
+
+func (r *fileOutboxRepository) SaveCheckpoint(ctx context.Context, subscriber string, offset uint64) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.OutboxRepository.SaveCheckpoint(ctx, subscriber, offset)
+	}, change{collection: checkpointsCollection, id: subscriber, value: &outboxCheckpoint{Subscriber: subscriber, Offset: offset}})
+}
+
+// sequenceID は通し番号で採番するエンティティの ID です
+// スナップショット上で並びが分かるよう0埋めします
+func sequenceID(seq uint64) string {
+	return fmt.Sprintf("%020d", seq)
+}
@@ -0,0 +1,56 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const relationshipsCollection = "relationships"
+
+// fileRelationshipRepository は RelationshipRepository のファイル永続化実装です
+type fileRelationshipRepository struct {
+	repository.RelationshipRepository
+	store *Store
+}
+
+// NewFileRelationshipRepository は保存済みのリレーションシップを読み込み、fileRelationshipRepository を生成します
+func NewFileRelationshipRepository(store *Store) (repository.RelationshipRepository, error) {
+	inner := inmemory.NewInMemoryRelationshipRepository()
+	err := load(store, relationshipsCollection, func(relationship *domain.Relationship) error {
+		return inner.Create(context.Background(), relationship)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileRelationshipRepository{RelationshipRepository: inner, store: store}, nil
+}
+
+func (r *fileRelationshipRepository) Create(ctx context.Context, relationship *domain.Relationship) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.RelationshipRepository.Create(ctx, relationship)
+	}, putRelationship(relationship))
+}
+
+func (r *fileRelationshipRepository) Update(ctx context.Context, relationship *domain.Relationship) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.RelationshipRepository.Update(ctx, relationship)
+	}, putRelationship(relationship))
+}
+
+func (r *fileRelationshipRepository) Delete(ctx context.Context, relationship *domain.Relationship) error {
+	return r.DeleteByID(ctx, relationship.ID)
+}
+
+func (r *fileRelationshipRepository) DeleteByID(ctx context.Context, id domain.RelationshipID) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.RelationshipRepository.DeleteByID(ctx, id)
+	}, change{collection: relationshipsCollection, id: id.String()})
+}
+
+func putRelationship(relationship *domain.Relationship) change {
+	return change{collection: relationshipsCollection, id: relationship.ID.String(), value: relationship}
+}
@@ -0,0 +1,43 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const sessionsCollection = "sessions"
+
+// fileSessionRepository は SessionRepository のファイル永続化実装です
+// トークンはハッシュのみを保存します
+type fileSessionRepository struct {
+	repository.SessionRepository
+	store *Store
+}
+
+// NewFileSessionRepository は保存済みのセッションを読み込み、fileSessionRepository を生成します
+func NewFileSessionRepository(store *Store) (repository.SessionRepository, error) {
+	inner := inmemory.NewInMemorySessionRepository()
+	err := load(store, sessionsCollection, func(session *domain.Session) error {
+		return inner.Create(context.Background(), session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSessionRepository{SessionRepository: inner, store: store}, nil
+}
+
+func (r *fileSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.SessionRepository.Create(ctx, session)
+	}, change{collection: sessionsCollection, id: session.TokenHash, value: session})
+}
+
+func (r *fileSessionRepository) Delete(ctx context.Context, tokenHash string) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.SessionRepository.Delete(ctx, tokenHash)
+	}, change{collection: sessionsCollection, id: tokenHash})
+}
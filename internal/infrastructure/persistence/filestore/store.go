@@ -0,0 +1,394 @@
+// Package filestore は標準ライブラリのみで実装したファイル永続化バックエンドです
+//
+// 変更は書き込み（トランザクション）ごとに追記専用のジャーナルへ1行で書き込み、fsync してから呼び出し元に返します。
+// ジャーナルが一定件数に達するとその時点の全データをスナップショットとして書き出し
+// （一時ファイルへの書き込み・fsync・rename）、ジャーナルを空にします。
+// 起動時はスナップショットを読み込んだ後、それより新しいジャーナルを再生します。
+//
+// 読み取りとインデックスはインメモリリポジトリに任せ、このパッケージのリポジトリは
+// 書き込みをジャーナルに記録する役割だけを持ちます。
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const (
+	journalFileName  = "journal.log"
+	snapshotFileName = "snapshot.json"
+
+	// defaultCompactThreshold はスナップショットを作成するジャーナルの件数の既定値です
+	defaultCompactThreshold = 1000
+)
+
+// ErrStoreFailed is returned for every write after the journal could not be written.
+// A failed fsync cannot be safely retried, so the store stops accepting writes
+// and the process should be restarted to recover from the last durable state.
+var ErrStoreFailed = errors.New("file store is unavailable after a write failure")
+
+// Options configures a Store
+type Options struct {
+	// CompactThreshold はスナップショットを作成するまでに溜めるジャーナルの件数です（0 の場合は既定値）
+	CompactThreshold int
+	Logger           *slog.Logger
+}
+
+// Store は全リポジトリで共有するジャーナルとスナップショットを管理します
+// コレクション名ごとに ID → エンティティ(JSON) を保持し、スナップショットの内容とします
+type Store struct {
+	dir       string
+	threshold int
+	logger    *slog.Logger
+
+	// txMu はトランザクションとトランザクション外の書き込みを直列化します
+	txMu sync.Mutex
+	// inner はインメモリリポジトリへの反映をロールバックするための TxManager です
+	inner repository.TxManager
+
+	mu      sync.Mutex
+	journal *os.File
+	seq     uint64
+	pending int // 最後のスナップショット以降のジャーナルのレコード件数
+	failed  error
+	state   map[string]map[string]json.RawMessage
+}
+
+// snapshot はスナップショットファイルの内容です
+type snapshot struct {
+	Seq         uint64                                `json:"seq"`
+	Collections map[string]map[string]json.RawMessage `json:"collections"`
+}
+
+// change はリポジトリからの1件の変更です。value が nil の場合は削除を表します
+type change struct {
+	collection string
+	id         string
+	value      any
+}
+
+// Open はディレクトリ内のデータを読み込み、Store を生成します
+func Open(dir string, opts Options) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	s := &Store{
+		dir:       dir,
+		threshold: opts.CompactThreshold,
+		logger:    opts.Logger,
+		state:     make(map[string]map[string]json.RawMessage),
+		inner:     inmemory.NewInMemoryTxManager(),
+	}
+	if s.threshold <= 0 {
+		s.threshold = defaultCompactThreshold
+	}
+	if s.logger == nil {
+		s.logger = slog.Default()
+	}
+
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.replayJournal(); err != nil {
+		return nil, err
+	}
+
+	journal, err := os.OpenFile(s.path(journalFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	s.journal = journal
+
+	return s, nil
+}
+
+// Close はスナップショットを作成してジャーナルを閉じます
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	if s.failed == nil && s.pending > 0 {
+		err = s.compact()
+	}
+	return errors.Join(err, s.journal.Close())
+}
+
+// Compact はスナップショットを作成し、ジャーナルを空にします
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed != nil {
+		return s.failed
+	}
+	return s.compact()
+}
+
+// write はインメモリへの反映 apply が成功した場合に変更をジャーナルに記録します
+// ctx がトランザクション内の場合はレコードを溜めておき、コミット時にまとめて記録します。
+// それ以外の場合は1件のトランザクションとして直ちに記録します
+// apply には渡された ctx を使い、記録に失敗した場合にインメモリへの反映がロールバックされるようにします
+func (s *Store) write(ctx context.Context, apply func(ctx context.Context) error, changes ...change) error {
+	return s.writeChanges(ctx, func(ctx context.Context) ([]change, error) {
+		if err := apply(ctx); err != nil {
+			return nil, err
+		}
+		return changes, nil
+	})
+}
+
+// writeChanges は write と同様ですが、記録する変更を apply の結果から決めます
+// ID をインメモリ側で採番する場合に使います
+func (s *Store) writeChanges(ctx context.Context, apply func(ctx context.Context) ([]change, error)) error {
+	if tx := s.txFromContext(ctx); tx != nil {
+		return s.stage(ctx, tx, apply)
+	}
+
+	// トランザクション外の書き込みも1件のトランザクションとして扱い、記録に失敗したらインメモリの変更を戻す
+	return s.do(ctx, func(ctx context.Context) error {
+		return s.writeChanges(ctx, apply)
+	})
+}
+
+// do は fn をトランザクション内で実行し、fn が成功した場合に溜めたレコードをジャーナルに記録します
+// インメモリへの反映とロールバックはインメモリの TxManager に任せます
+func (s *Store) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 入れ子の場合は外側のトランザクションに参加する
+	if s.txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	tx := &fileTx{store: s}
+	return s.inner.Do(context.WithValue(ctx, txKey{}, tx), func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		// 記録に失敗した場合はエラーを返し、インメモリの変更もロールバックさせる
+		return s.commit(tx)
+	})
+}
+
+// stage は apply を実行し、変更をエンコードしてトランザクションに追加します
+func (s *Store) stage(ctx context.Context, tx *fileTx, apply func(ctx context.Context) ([]change, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed != nil {
+		return s.failed
+	}
+
+	changes, err := apply(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		rec := record{Collection: c.collection, ID: c.id, Op: opDelete}
+		if c.value != nil {
+			data, err := json.Marshal(c.value)
+			if err != nil {
+				return s.fail(fmt.Errorf("failed to encode %s %s: %w", c.collection, c.id, err))
+			}
+			rec.Op = opPut
+			rec.Data = data
+		}
+		tx.records = append(tx.records, rec)
+	}
+	return nil
+}
+
+// commit はトランザクションのレコードをジャーナルの1行として記録します
+// 複数リポジトリの書き込みを直列化し、ジャーナルの順序とメモリ上の状態を一致させます
+func (s *Store) commit(tx *fileTx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed != nil {
+		return s.failed
+	}
+	if len(tx.records) == 0 {
+		return nil
+	}
+
+	e := entry{Seq: s.seq + 1, Records: tx.records}
+	if err := appendEntry(s.journal, e); err != nil {
+		return s.fail(err)
+	}
+	s.seq = e.Seq
+	for _, rec := range e.Records {
+		s.apply(rec)
+	}
+
+	s.pending += len(e.Records)
+	if s.pending >= s.threshold {
+		// スナップショットの失敗はジャーナルが残っているためデータ損失にはならない
+		if err := s.compact(); err != nil {
+			s.logger.Error("failed to compact file store", "error", err)
+		}
+	}
+	return nil
+}
+
+// fail はジャーナルへの書き込み失敗を記録し、以降の書き込みを拒否します
+func (s *Store) fail(err error) error {
+	s.logger.Error("file store write failed, rejecting further writes", "error", err)
+	s.failed = fmt.Errorf("%w: %w", ErrStoreFailed, err)
+	return s.failed
+}
+
+// apply はジャーナルのレコード1件を保持しているデータに反映します
+func (s *Store) apply(rec record) {
+	entities, ok := s.state[rec.Collection]
+	if !ok {
+		entities = make(map[string]json.RawMessage)
+		s.state[rec.Collection] = entities
+	}
+
+	switch rec.Op {
+	case opPut:
+		entities[rec.ID] = rec.Data
+	case opDelete:
+		delete(entities, rec.ID)
+	}
+}
+
+// compact は現在の全データをスナップショットとして書き出し、ジャーナルを空にします
+// スナップショットの置き換え後にクラッシュしても、再生時に seq で古いジャーナルを読み飛ばします
+func (s *Store) compact() error {
+	data, err := json.Marshal(snapshot{Seq: s.seq, Collections: s.state})
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := writeFileAtomic(s.path(snapshotFileName), data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path(journalFileName), nil); err != nil {
+		return fmt.Errorf("failed to truncate journal: %w", err)
+	}
+	journal, err := os.OpenFile(s.path(journalFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return s.fail(fmt.Errorf("failed to reopen journal: %w", err))
+	}
+	s.journal.Close()
+	s.journal = journal
+	s.pending = 0
+
+	return nil
+}
+
+func (s *Store) loadSnapshot() error {
+	data, err := os.ReadFile(s.path(snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	s.seq = snap.Seq
+	for collection, entities := range snap.Collections {
+		if entities != nil {
+			s.state[collection] = entities
+		}
+	}
+	return nil
+}
+
+func (s *Store) replayJournal() error {
+	entries, err := readJournal(s.path(journalFileName), s.logger)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		// スナップショットに含まれている変更は読み飛ばす
+		if e.Seq <= s.seq {
+			continue
+		}
+		for _, rec := range e.Records {
+			s.apply(rec)
+		}
+		s.seq = e.Seq
+		s.pending += len(e.Records)
+	}
+	return nil
+}
+
+// load はコレクション内の全エンティティを読み込み、fn に渡します
+func load[T any](s *Store, collection string, fn func(*T) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, data := range s.state[collection] {
+		entity := new(T)
+		if err := json.Unmarshal(data, entity); err != nil {
+			return fmt.Errorf("failed to decode %s %s: %w", collection, id, err)
+		}
+		if err := fn(entity); err != nil {
+			return fmt.Errorf("failed to load %s %s: %w", collection, id, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+// writeFileAtomic は一時ファイルに書き込んで fsync した後に rename で置き換えます
+// rename 自体を永続化するためディレクトリも fsync します
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
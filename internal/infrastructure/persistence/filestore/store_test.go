@@ -0,0 +1,288 @@
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+func openTestStore(t *testing.T, dir string, threshold int) (*Store, repository.UserRepository) {
+	t.Helper()
+
+	store, err := Open(dir, Options{CompactThreshold: threshold, Logger: discardLogger})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	users, err := NewFileUserRepository(store)
+	if err != nil {
+		t.Fatalf("NewFileUserRepository: %v", err)
+	}
+	return store, users
+}
+
+func createTestUser(t *testing.T, users repository.UserRepository, id domain.UserID) {
+	t.Helper()
+
+	user := &domain.User{ID: id, Username: string(id), Email: string(id) + "@example.com"}
+	if err := users.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create(%s): %v", id, err)
+	}
+}
+
+func renameUser(ctx context.Context, users repository.UserRepository, id domain.UserID, username string) error {
+	user, err := users.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	user.Username = username
+	return users.Update(ctx, user)
+}
+
+func renameTestUser(t *testing.T, users repository.UserRepository, id domain.UserID, username string) {
+	t.Helper()
+
+	if err := renameUser(context.Background(), users, id, username); err != nil {
+		t.Fatalf("Update(%s): %v", id, err)
+	}
+}
+
+func assertUsername(t *testing.T, users repository.UserRepository, id domain.UserID, want string) {
+	t.Helper()
+
+	user, err := users.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID(%s): %v", id, err)
+	}
+	if user.Username != want {
+		t.Errorf("%s Username = %q, want %q", id, user.Username, want)
+	}
+}
+
+// assertUsers は want のユーザーが存在し、missing のユーザーが存在しないことを確認します
+func assertUsers(t *testing.T, users repository.UserRepository, want []domain.UserID, missing ...domain.UserID) {
+	t.Helper()
+
+	for _, id := range want {
+		if _, err := users.FindByID(context.Background(), id); err != nil {
+			t.Errorf("FindByID(%s): %v", id, err)
+		}
+	}
+	for _, id := range missing {
+		if _, err := users.FindByID(context.Background(), id); !apperrors.IsNotFoundError(err) {
+			t.Errorf("FindByID(%s) error = %v, want not found", id, err)
+		}
+	}
+}
+
+// 閉じずに再度開くと、ジャーナルから変更を再生する
+func TestStore_ReplaysJournal(t *testing.T) {
+	dir := t.TempDir()
+	store, users := openTestStore(t, dir, 0)
+	createTestUser(t, users, "alice")
+	createTestUser(t, users, "bob")
+
+	renameTestUser(t, users, "alice", "alice2")
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("snapshot exists before compaction: %v", err)
+	}
+
+	reopened, users := openTestStore(t, dir, 0)
+	defer reopened.Close()
+	defer store.journal.Close()
+
+	assertUsers(t, users, []domain.UserID{"alice", "bob"})
+	assertUsername(t, users, "alice", "alice2")
+	if reopened.seq != 3 || reopened.pending != 3 {
+		t.Errorf("seq, pending = %d, %d, want 3, 3", reopened.seq, reopened.pending)
+	}
+}
+
+// 件数がしきい値に達するとスナップショットを作成し、ジャーナルを空にする
+func TestStore_Compacts(t *testing.T) {
+	dir := t.TempDir()
+	store, users := openTestStore(t, dir, 2)
+	createTestUser(t, users, "alice")
+	createTestUser(t, users, "bob")
+
+	data, err := os.ReadFile(filepath.Join(dir, snapshotFileName))
+	if err != nil {
+		t.Fatalf("ReadFile(snapshot): %v", err)
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("Unmarshal(snapshot): %v", err)
+	}
+	if snap.Seq != 2 || len(snap.Collections[usersCollection]) != 2 {
+		t.Errorf("snapshot seq = %d, users = %d, want 2, 2", snap.Seq, len(snap.Collections[usersCollection]))
+	}
+	if size := fileSize(t, filepath.Join(dir, journalFileName)); size != 0 {
+		t.Errorf("journal size = %d, want 0 after compaction", size)
+	}
+
+	// 圧縮後の書き込みは新しいジャーナルに記録される
+	createTestUser(t, users, "carol")
+	if err := store.journal.Close(); err != nil {
+		t.Fatalf("Close journal: %v", err)
+	}
+
+	reopened, users := openTestStore(t, dir, 2)
+	defer reopened.Close()
+	assertUsers(t, users, []domain.UserID{"alice", "bob", "carol"})
+
+	// 一時ファイルは残さない
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("files = %v, want only the snapshot and the journal", files)
+	}
+}
+
+func TestStore_CloseCompacts(t *testing.T) {
+	dir := t.TempDir()
+	store, users := openTestStore(t, dir, 0)
+	createTestUser(t, users, "alice")
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if size := fileSize(t, filepath.Join(dir, journalFileName)); size != 0 {
+		t.Errorf("journal size = %d, want 0 after Close", size)
+	}
+	reopened, users := openTestStore(t, dir, 0)
+	defer reopened.Close()
+	assertUsers(t, users, []domain.UserID{"alice"})
+}
+
+// スナップショットの置き換え後、ジャーナルを空にする前にクラッシュした場合は
+// スナップショットに含まれるエントリを読み飛ばす
+func TestStore_SkipsJournalEntriesInSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	store, users := openTestStore(t, dir, 0)
+	createTestUser(t, users, "alice")
+	createTestUser(t, users, "bob")
+	journal, err := os.ReadFile(filepath.Join(dir, journalFileName))
+	if err != nil {
+		t.Fatalf("ReadFile(journal): %v", err)
+	}
+
+	// スナップショットに含まれた後の変更は、古いジャーナルを再生しても元に戻らない
+	renameTestUser(t, users, "bob", "bob2")
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, journalFileName), journal, 0o600); err != nil {
+		t.Fatalf("WriteFile(journal): %v", err)
+	}
+
+	reopened, users := openTestStore(t, dir, 0)
+	defer reopened.Close()
+	assertUsername(t, users, "bob", "bob2")
+	if reopened.seq != 3 || reopened.pending != 0 {
+		t.Errorf("seq, pending = %d, %d, want 3, 0", reopened.seq, reopened.pending)
+	}
+}
+
+// ジャーナルへの書き込みに失敗した場合はインメモリへの反映を取り消し、以降の書き込みを拒否する
+func TestStore_RollsBackFailedCommit(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(ctx context.Context, store *Store, users repository.UserRepository) error
+	}{
+		{
+			name: "outside a transaction",
+			write: func(ctx context.Context, _ *Store, users repository.UserRepository) error {
+				return users.Create(ctx, &domain.User{ID: "bob", Username: "bob", Email: "bob@example.com"})
+			},
+		},
+		{
+			name: "in a transaction",
+			write: func(ctx context.Context, store *Store, users repository.UserRepository) error {
+				return NewFileTxManager(store).Do(ctx, func(ctx context.Context) error {
+					if err := users.Create(ctx, &domain.User{ID: "bob", Username: "bob", Email: "bob@example.com"}); err != nil {
+						return err
+					}
+					return renameUser(ctx, users, "alice", "alice2")
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			store, users := openTestStore(t, dir, 0)
+			createTestUser(t, users, "alice")
+			size := fileSize(t, filepath.Join(dir, journalFileName))
+
+			// ジャーナルを閉じて書き込みを失敗させる
+			if err := store.journal.Close(); err != nil {
+				t.Fatalf("Close journal: %v", err)
+			}
+			if err := tt.write(ctx, store, users); !errors.Is(err, ErrStoreFailed) {
+				t.Fatalf("write error = %v, want ErrStoreFailed", err)
+			}
+			assertUsers(t, users, []domain.UserID{"alice"}, "bob")
+			assertUsername(t, users, "alice", "alice")
+
+			err := users.Create(ctx, &domain.User{ID: "carol", Username: "carol", Email: "carol@example.com"})
+			if !errors.Is(err, ErrStoreFailed) {
+				t.Errorf("later write error = %v, want ErrStoreFailed", err)
+			}
+			assertUsers(t, users, nil, "carol")
+			if err := store.Compact(); !errors.Is(err, ErrStoreFailed) {
+				t.Errorf("Compact error = %v, want ErrStoreFailed", err)
+			}
+
+			if got := fileSize(t, filepath.Join(dir, journalFileName)); got != size {
+				t.Errorf("journal size = %d, want %d", got, size)
+			}
+			reopened, users := openTestStore(t, dir, 0)
+			defer reopened.Close()
+			assertUsers(t, users, []domain.UserID{"alice"}, "bob", "carol")
+			assertUsername(t, users, "alice", "alice")
+		})
+	}
+}
+
+// fn がエラーを返したトランザクションは何も記録しない
+func TestStore_TransactionErrorRollsBack(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, users := openTestStore(t, dir, 0)
+	defer store.Close()
+	createTestUser(t, users, "alice")
+	size := fileSize(t, filepath.Join(dir, journalFileName))
+
+	errAbort := errors.New("abort")
+	err := NewFileTxManager(store).Do(ctx, func(ctx context.Context) error {
+		if err := users.Create(ctx, &domain.User{ID: "bob", Username: "bob", Email: "bob@example.com"}); err != nil {
+			return err
+		}
+		if err := renameUser(ctx, users, "alice", "alice2"); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Do error = %v, want %v", err, errAbort)
+	}
+
+	assertUsers(t, users, []domain.UserID{"alice"}, "bob")
+	assertUsername(t, users, "alice", "alice")
+	if got := fileSize(t, filepath.Join(dir, journalFileName)); got != size {
+		t.Errorf("journal size = %d, want %d", got, size)
+	}
+
+	// 失敗ではないため、以降の書き込みは受け付ける
+	createTestUser(t, users, "carol")
+}
@@ -0,0 +1,38 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/repository"
+)
+
+type txKey struct{}
+
+// fileTx はトランザクション中にジャーナルへ記録するレコードを溜めておきます
+type fileTx struct {
+	store   *Store
+	records []record
+}
+
+// fileTxManager は TxManager のファイル永続化実装です
+// コミット時に全レコードをジャーナルの1行として記録します
+type fileTxManager struct {
+	store *Store
+}
+
+// NewFileTxManager は store に書き込むリポジトリ用の fileTxManager を生成します
+func NewFileTxManager(store *Store) repository.TxManager {
+	return &fileTxManager{store: store}
+}
+
+func (m *fileTxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.store.do(ctx, fn)
+}
+
+// txFromContext は ctx がこの Store のトランザクション内であればそのトランザクションを返します
+func (s *Store) txFromContext(ctx context.Context) *fileTx {
+	if tx, ok := ctx.Value(txKey{}).(*fileTx); ok && tx.store == s {
+		return tx
+	}
+	return nil
+}
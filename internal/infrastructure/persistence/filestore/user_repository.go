@@ -0,0 +1,62 @@
+package filestore
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+)
+
+const usersCollection = "users"
+
+// fileUserRepository は UserRepository のファイル永続化実装です
+type fileUserRepository struct {
+	repository.UserRepository
+	store *Store
+}
+
+// NewFileUserRepository は保存済みのユーザーを読み込み、fileUserRepository を生成します
+func NewFileUserRepository(store *Store) (repository.UserRepository, error) {
+	inner := inmemory.NewInMemoryUserRepository()
+	err := load(store, usersCollection, func(user *domain.User) error {
+		return inner.Create(context.Background(), user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileUserRepository{UserRepository: inner, store: store}, nil
+}
+
+func (r *fileUserRepository) Create(ctx context.Context, user *domain.User) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.UserRepository.Create(ctx, user)
+	}, putUser(user))
+}
+
+func (r *fileUserRepository) Update(ctx context.Context, user *domain.User) error {
+	return r.store.write(ctx, func(ctx context.Context) error {
+		return r.UserRepository.Update(ctx, user)
+	}, putUser(user))
+}
+
+func (r *fileUserRepository) UpdateRelatedUsers(ctx context.Context, userID domain.UserID, relatedUsers []domain.RelatedUser) error {
+	// 更新後のユーザー全体を記録する
+	user := &domain.User{}
+	return r.store.write(ctx, func(ctx context.Context) error {
+		if err := r.UserRepository.UpdateRelatedUsers(ctx, userID, relatedUsers); err != nil {
+			return err
+		}
+		updated, err := r.UserRepository.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		*user = *updated
+		return nil
+	}, change{collection: usersCollection, id: string(userID), value: user})
+}
+
+func putUser(user *domain.User) change {
+	return change{collection: usersCollection, id: string(user.ID), value: user}
+}
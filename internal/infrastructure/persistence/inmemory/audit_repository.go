@@ -0,0 +1,87 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+)
+
+// inMemoryAuditRepository は AuditRepository のインメモリ実装です
+// エントリは追記のみで、ロールバック以外で取り除くことはありません
+type inMemoryAuditRepository struct {
+	mu      sync.RWMutex
+	entries []*domain.AuditEntry // Seq の昇順
+	lastSeq uint64
+}
+
+func NewInMemoryAuditRepository() repository.AuditRepository {
+	return &inMemoryAuditRepository{}
+}
+
+func (r *inMemoryAuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prevLast := r.lastSeq
+	// 読み込み時など既に通し番号を持つ場合はそのまま保存する
+	if entry.Seq == 0 {
+		entry.Seq = r.lastSeq + 1
+	}
+	r.lastSeq = max(r.lastSeq, entry.Seq)
+
+	cp := copyAuditEntry(entry)
+	i, _ := slices.BinarySearchFunc(r.entries, cp.Seq, func(e *domain.AuditEntry, seq uint64) int {
+		return compareSeq(e.Seq, seq)
+	})
+	r.entries = slices.Insert(r.entries, i, cp)
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.entries = slices.DeleteFunc(r.entries, func(e *domain.AuditEntry) bool {
+			return e.Seq == cp.Seq
+		})
+		r.lastSeq = prevLast
+	})
+	return nil
+}
+
+func (r *inMemoryAuditRepository) ListByUser(ctx context.Context, userID domain.UserID, limit int) ([]*domain.AuditEntry, error) {
+	return r.listNewest(limit, func(e *domain.AuditEntry) bool {
+		return e.Involves(userID)
+	}), nil
+}
+
+func (r *inMemoryAuditRepository) ListByEntity(ctx context.Context, entityID string, limit int) ([]*domain.AuditEntry, error) {
+	return r.listNewest(limit, func(e *domain.AuditEntry) bool {
+		return e.EntityID == entityID
+	}), nil
+}
+
+// listNewest は条件に合うエントリを新しい順に最大 limit 件返します
+func (r *inMemoryAuditRepository) listNewest(limit int, match func(*domain.AuditEntry) bool) []*domain.AuditEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.AuditEntry
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		if match(r.entries[i]) {
+			result = append(result, copyAuditEntry(r.entries[i]))
+		}
+	}
+	return result
+}
+
+// copyAuditEntry は呼び出し側の変更がストアに影響しないようコピーを返します
+func copyAuditEntry(entry *domain.AuditEntry) *domain.AuditEntry {
+	cp := *entry
+	cp.TargetUserIDs = slices.Clone(entry.TargetUserIDs)
+	return &cp
+}
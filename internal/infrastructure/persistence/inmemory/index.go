@@ -0,0 +1,26 @@
+package inmemory
+
+// idSet はセカンダリインデックスの値として使うIDの集合です
+type idSet[ID comparable] map[ID]struct{}
+
+// addToIDSet は key のIDセットに id を追加します。セットがなければ作成します
+func addToIDSet[K, ID comparable](index map[K]idSet[ID], key K, id ID) {
+	ids, ok := index[key]
+	if !ok {
+		ids = make(idSet[ID])
+		index[key] = ids
+	}
+	ids[id] = struct{}{}
+}
+
+// removeFromIDSet は key のIDセットから id を取り除きます。空になったセットは削除します
+func removeFromIDSet[K, ID comparable](index map[K]idSet[ID], key K, id ID) {
+	ids, ok := index[key]
+	if !ok {
+		return
+	}
+	delete(ids, id)
+	if len(ids) == 0 {
+		delete(index, key)
+	}
+}
@@ -2,20 +2,42 @@ package inmemory
 
 import (
 	"context"
-	"fmt"
 	"morning-call/internal/domain"
 	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+	"slices"
 	"sync"
+	"time"
 )
 
+// dueBucketSize は配信待ちインデックスで配信時刻をまとめる単位です
+const dueBucketSize = time.Hour
+
+// morningCallIDSet is a set of morning call IDs used for secondary indexes
+type morningCallIDSet = idSet[domain.MorningCallID]
+
+// inMemoryMorningCallRepository は MorningCallRepository のインメモリ実装です
+// 送信者・受信者・ステータス・繰り返し設定ごとのインデックスと、配信待ちのモーニングコールを
+// 配信時刻の時間帯ごとにまとめたインデックスを保持し、一覧の取得を全件走査せずに行います
 type inMemoryMorningCallRepository struct {
 	mu           sync.RWMutex
 	morningCalls map[domain.MorningCallID]*domain.MorningCall
+	bySender     map[domain.UserID]morningCallIDSet
+	byReceiver   map[domain.UserID]morningCallIDSet
+	byStatus     map[domain.MorningCallStatus]morningCallIDSet
+	bySeries     map[domain.MorningCallSeriesID]morningCallIDSet
+	// dueBuckets は配信待ち（予定・スヌーズ中）のモーニングコールのみを保持する
+	dueBuckets map[int64]morningCallIDSet
 }
 
 func NewInMemoryMorningCallRepository() repository.MorningCallRepository {
 	return &inMemoryMorningCallRepository{
 		morningCalls: make(map[domain.MorningCallID]*domain.MorningCall),
+		bySender:     make(map[domain.UserID]morningCallIDSet),
+		byReceiver:   make(map[domain.UserID]morningCallIDSet),
+		byStatus:     make(map[domain.MorningCallStatus]morningCallIDSet),
+		bySeries:     make(map[domain.MorningCallSeriesID]morningCallIDSet),
+		dueBuckets:   make(map[int64]morningCallIDSet),
 	}
 }
 
@@ -25,9 +47,9 @@ func (r *inMemoryMorningCallRepository) FindByID(ctx context.Context, id domain.
 
 	morningCall, ok := r.morningCalls[id]
 	if !ok {
-		return nil, fmt.Errorf("morning call not found: %s", id)
+		return nil, apperrors.NotFoundError("morning call").WithDetails("id", id)
 	}
-	return morningCall, nil
+	return copyMorningCall(morningCall), nil
 }
 
 func (r *inMemoryMorningCallRepository) Save(ctx context.Context, morningCall *domain.MorningCall) error {
@@ -35,10 +57,18 @@ func (r *inMemoryMorningCallRepository) Save(ctx context.Context, morningCall *d
 	defer r.mu.Unlock()
 
 	if morningCall.ID == "" {
-		return fmt.Errorf("morning call ID is required")
+		return apperrors.ValidationError("id", "morning call ID is required")
+	}
+	if _, ok := r.morningCalls[morningCall.ID]; ok {
+		return apperrors.ConflictError("morning call").WithDetails("id", morningCall.ID)
 	}
 
-	r.morningCalls[morningCall.ID] = morningCall
+	// 新規作成時のバージョンは1。読み込み時など既にバージョンを持つ場合はそのまま保存する
+	if morningCall.Version == 0 {
+		morningCall.Version = 1
+	}
+	r.index(copyMorningCall(morningCall))
+	onRollback(ctx, func() { r.restore(morningCall.ID, nil) })
 	return nil
 }
 
@@ -46,11 +76,20 @@ func (r *inMemoryMorningCallRepository) Update(ctx context.Context, morningCall
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.morningCalls[morningCall.ID]; !ok {
-		return fmt.Errorf("morning call not found: %s", morningCall.ID)
+	existing, ok := r.morningCalls[morningCall.ID]
+	if !ok {
+		return apperrors.NotFoundError("morning call").WithDetails("id", morningCall.ID)
+	}
+
+	// 取得後に他の処理で更新されていれば上書きしない
+	if existing.Version != morningCall.Version {
+		return apperrors.VersionConflictError("morning call", morningCall.Version, existing.Version).WithDetails("id", morningCall.ID)
 	}
 
-	r.morningCalls[morningCall.ID] = morningCall
+	morningCall.Version++
+	r.unindex(existing)
+	r.index(copyMorningCall(morningCall))
+	onRollback(ctx, func() { r.restore(morningCall.ID, existing) })
 	return nil
 }
 
@@ -58,36 +97,187 @@ func (r *inMemoryMorningCallRepository) Delete(ctx context.Context, id domain.Mo
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.morningCalls[id]; !ok {
-		return fmt.Errorf("morning call not found: %s", id)
+	existing, ok := r.morningCalls[id]
+	if !ok {
+		return apperrors.NotFoundError("morning call").WithDetails("id", id)
 	}
 
-	delete(r.morningCalls, id)
+	r.unindex(existing)
+	onRollback(ctx, func() { r.restore(id, existing) })
 	return nil
 }
 
+// restore はロールバック時にモーニングコールを以前の状態に戻します。prev が nil の場合は削除します
+func (r *inMemoryMorningCallRepository) restore(id domain.MorningCallID, prev *domain.MorningCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, ok := r.morningCalls[id]; ok {
+		r.unindex(current)
+	}
+	if prev != nil {
+		r.index(prev)
+	}
+}
+
 func (r *inMemoryMorningCallRepository) ListBySenderID(ctx context.Context, senderID domain.UserID) ([]*domain.MorningCall, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	return r.collect(r.bySender[senderID], nil), nil
+}
+
+func (r *inMemoryMorningCallRepository) ListByReceiverID(ctx context.Context, receiverID domain.UserID) ([]*domain.MorningCall, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.collect(r.byReceiver[receiverID], nil), nil
+}
+
+func (r *inMemoryMorningCallRepository) ListByReceiverBetween(ctx context.Context, receiverID domain.UserID, from, to time.Time) ([]*domain.MorningCall, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var result []*domain.MorningCall
-	for _, mc := range r.morningCalls {
-		if mc.SenderID == senderID {
-			result = append(result, mc)
+	for id := range r.byReceiver[receiverID] {
+		if mc := r.morningCalls[id]; !mc.Time.Before(from) && mc.Time.Before(to) {
+			result = append(result, copyMorningCall(mc))
 		}
 	}
 	return result, nil
 }
 
-func (r *inMemoryMorningCallRepository) ListByReceiverID(ctx context.Context, receiverID domain.UserID) ([]*domain.MorningCall, error) {
+func (r *inMemoryMorningCallRepository) ListDueBefore(ctx context.Context, t time.Time) ([]*domain.MorningCall, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	// t を含む時間帯までの配信待ちのみを確認する
+	last := dueBucket(t)
 	var result []*domain.MorningCall
-	for _, mc := range r.morningCalls {
-		if mc.ReceiverID == receiverID {
+	for bucket, ids := range r.dueBuckets {
+		if bucket > last {
+			continue
+		}
+		for id := range ids {
+			if mc := r.morningCalls[id]; !mc.FireTime().After(t) {
+				result = append(result, copyMorningCall(mc))
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *inMemoryMorningCallRepository) ListByStatus(ctx context.Context, status domain.MorningCallStatus) ([]*domain.MorningCall, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.collect(r.byStatus[status], nil), nil
+}
+
+func (r *inMemoryMorningCallRepository) ListBySeriesID(ctx context.Context, seriesID domain.MorningCallSeriesID) ([]*domain.MorningCall, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.collect(r.bySeries[seriesID], nil), nil
+}
+
+func (r *inMemoryMorningCallRepository) Search(ctx context.Context, query domain.MorningCallQuery) ([]*domain.MorningCall, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// 検索対象はユーザーが送信・受信したものに限られるため、インデックスから候補を取り出す
+	var candidates []morningCallIDSet
+	if query.Direction != domain.MorningCallDirectionReceived {
+		candidates = append(candidates, r.bySender[query.UserID])
+	}
+	if query.Direction != domain.MorningCallDirectionSent {
+		candidates = append(candidates, r.byReceiver[query.UserID])
+	}
+
+	var result []*domain.MorningCall
+	for i, ids := range candidates {
+		for id := range ids {
+			mc := r.morningCalls[id]
+			// 送信・受信の両方に含まれるものは一度だけ返す
+			if i > 0 && len(candidates) > 1 && mc.SenderID == query.UserID {
+				continue
+			}
+			if !query.Matches(mc) {
+				continue
+			}
+			// 前のページまでに返したものは除く
+			if query.After != nil && !query.Less(*query.After, domain.CursorOf(mc)) {
+				continue
+			}
 			result = append(result, mc)
 		}
 	}
+
+	slices.SortFunc(result, func(a, b *domain.MorningCall) int {
+		if query.Less(domain.CursorOf(a), domain.CursorOf(b)) {
+			return -1
+		}
+		return 1
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+
+	for i, mc := range result {
+		result[i] = copyMorningCall(mc)
+	}
 	return result, nil
 }
+
+// index はモーニングコールを保存し、各インデックスに登録します
+func (r *inMemoryMorningCallRepository) index(mc *domain.MorningCall) {
+	r.morningCalls[mc.ID] = mc
+	addToIDSet(r.bySender, mc.SenderID, mc.ID)
+	addToIDSet(r.byReceiver, mc.ReceiverID, mc.ID)
+	addToIDSet(r.byStatus, mc.Status, mc.ID)
+	if mc.SeriesID != "" {
+		addToIDSet(r.bySeries, mc.SeriesID, mc.ID)
+	}
+	if isDue(mc) {
+		addToIDSet(r.dueBuckets, dueBucket(mc.FireTime()), mc.ID)
+	}
+}
+
+// unindex はモーニングコールを削除し、各インデックスから取り除きます
+// インデックスのキーは保存時の値から求めるため、保存済みのモーニングコールを渡すこと
+func (r *inMemoryMorningCallRepository) unindex(mc *domain.MorningCall) {
+	delete(r.morningCalls, mc.ID)
+	removeFromIDSet(r.bySender, mc.SenderID, mc.ID)
+	removeFromIDSet(r.byReceiver, mc.ReceiverID, mc.ID)
+	removeFromIDSet(r.byStatus, mc.Status, mc.ID)
+	if mc.SeriesID != "" {
+		removeFromIDSet(r.bySeries, mc.SeriesID, mc.ID)
+	}
+	if isDue(mc) {
+		removeFromIDSet(r.dueBuckets, dueBucket(mc.FireTime()), mc.ID)
+	}
+}
+
+// collect はIDセットに含まれるモーニングコールのコピーを result に追加します
+func (r *inMemoryMorningCallRepository) collect(ids morningCallIDSet, result []*domain.MorningCall) []*domain.MorningCall {
+	for id := range ids {
+		result = append(result, copyMorningCall(r.morningCalls[id]))
+	}
+	return result
+}
+
+// isDue は配信待ちのステータスかどうかを返します
+func isDue(mc *domain.MorningCall) bool {
+	return mc.Status == domain.MorningCallStatusScheduled || mc.Status == domain.MorningCallStatusSnoozed
+}
+
+// dueBucket は配信時刻が属する時間帯を返します
+func dueBucket(t time.Time) int64 {
+	return t.Unix() / int64(dueBucketSize/time.Second)
+}
+
+// copyMorningCall は呼び出し側の変更がストアに影響しないようコピーを返します
+func copyMorningCall(mc *domain.MorningCall) *domain.MorningCall {
+	cp := *mc
+	return &cp
+}
@@ -0,0 +1,147 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+const (
+	// benchMorningCalls はベンチマークのモーニングコール数です
+	benchMorningCalls = 300_000
+	// benchSpan はモーニングコールの時刻を分布させる期間です
+	benchSpan = 30 * 24 * time.Hour
+	// benchDueWindow は ListDueBefore の対象期間で、全体の5% (約15,000件) になります
+	benchDueWindow = benchSpan / 20
+)
+
+var benchStart = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+var (
+	benchMorningCallRepoOnce sync.Once
+	benchMorningCallRepo     repository.MorningCallRepository
+)
+
+// morningCallRepositoryFixture は benchUsers 人の間で送受信された benchMorningCalls 件の
+// モーニングコールを保存したリポジトリを返します。ベンチマーク間で共有します
+func morningCallRepositoryFixture(b *testing.B) repository.MorningCallRepository {
+	b.Helper()
+
+	benchMorningCallRepoOnce.Do(func() {
+		ctx := context.Background()
+		rng := rand.New(rand.NewPCG(1, 2))
+		repo := NewInMemoryMorningCallRepository()
+		for i := range benchMorningCalls {
+			sender := rng.IntN(benchUsers)
+			receiver := (sender + 1 + rng.IntN(benchUsers-1)) % benchUsers
+			mc := &domain.MorningCall{
+				ID:         domain.MorningCallID(fmt.Sprintf("mc-%06d", i)),
+				SenderID:   benchUserID(sender),
+				ReceiverID: benchUserID(receiver),
+				Time:       benchStart.Add(time.Duration(rng.Int64N(int64(benchSpan/time.Minute))) * time.Minute),
+				Message:    "おはよう",
+				Status:     domain.MorningCallStatusScheduled,
+			}
+			if err := repo.Save(ctx, mc); err != nil {
+				b.Fatalf("Save: %v", err)
+			}
+		}
+		benchMorningCallRepo = repo
+	})
+	return benchMorningCallRepo
+}
+
+func BenchmarkListBySenderID(b *testing.B) {
+	repo := morningCallRepositoryFixture(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ListBySenderID(ctx, benchUserID(i%benchUsers)); err != nil {
+			b.Fatalf("ListBySenderID: %v", err)
+		}
+	}
+}
+
+func BenchmarkListByReceiverID(b *testing.B) {
+	repo := morningCallRepositoryFixture(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ListByReceiverID(ctx, benchUserID(i%benchUsers)); err != nil {
+			b.Fatalf("ListByReceiverID: %v", err)
+		}
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	repo := morningCallRepositoryFixture(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// 一覧APIの1ページ目と同じ条件
+		query := domain.MorningCallQuery{UserID: benchUserID(i % benchUsers), Limit: 20}
+		if _, err := repo.Search(ctx, query); err != nil {
+			b.Fatalf("Search: %v", err)
+		}
+	}
+}
+
+func BenchmarkListDueBefore(b *testing.B) {
+	repo := morningCallRepositoryFixture(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.ListDueBefore(ctx, benchStart.Add(benchDueWindow)); err != nil {
+			b.Fatalf("ListDueBefore: %v", err)
+		}
+	}
+}
+
+// 取得後に他の更新があった場合は上書きせず、競合とする
+func TestMorningCallRepository_UpdateChecksVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryMorningCallRepository()
+	err := repo.Save(ctx, &domain.MorningCall{ID: "mc", SenderID: "alice", ReceiverID: "bob", Time: benchStart, Status: domain.MorningCallStatusScheduled})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	first, _ := repo.FindByID(ctx, "mc")
+	stale, _ := repo.FindByID(ctx, "mc")
+
+	first.Message = "first"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Version = %d, want 2 after the update", first.Version)
+	}
+
+	stale.Message = "stale"
+	if err := repo.Update(ctx, stale); !apperrors.IsConflictError(err) {
+		t.Errorf("Update with a stale version error = %v, want a conflict", err)
+	}
+	stored, _ := repo.FindByID(ctx, "mc")
+	if stored.Message != "first" || stored.Version != 2 {
+		t.Errorf("stored = %q v%d, want \"first\" v2", stored.Message, stored.Version)
+	}
+
+	stored.Message = "second"
+	if err := repo.Update(ctx, stored); err != nil || stored.Version != 3 {
+		t.Errorf("Update with the current version = v%d, %v, want v3, nil", stored.Version, err)
+	}
+}
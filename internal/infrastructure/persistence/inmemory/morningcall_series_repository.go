@@ -0,0 +1,114 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// inMemoryMorningCallSeriesRepository は MorningCallSeriesRepository のインメモリ実装です
+type inMemoryMorningCallSeriesRepository struct {
+	mu     sync.RWMutex
+	series map[domain.MorningCallSeriesID]*domain.MorningCallSeries
+}
+
+// NewInMemoryMorningCallSeriesRepository は新しい inMemoryMorningCallSeriesRepository を生成します
+func NewInMemoryMorningCallSeriesRepository() repository.MorningCallSeriesRepository {
+	return &inMemoryMorningCallSeriesRepository{
+		series: make(map[domain.MorningCallSeriesID]*domain.MorningCallSeries),
+	}
+}
+
+func (r *inMemoryMorningCallSeriesRepository) FindByID(ctx context.Context, id domain.MorningCallSeriesID) (*domain.MorningCallSeries, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	series, ok := r.series[id]
+	if !ok {
+		return nil, apperrors.NotFoundError("morning call series").WithDetails("id", id)
+	}
+	return copySeries(series), nil
+}
+
+func (r *inMemoryMorningCallSeriesRepository) Save(ctx context.Context, series *domain.MorningCallSeries) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if series.ID == "" {
+		return apperrors.ValidationError("id", "morning call series ID is required")
+	}
+
+	prev := r.series[series.ID]
+	r.series[series.ID] = copySeries(series)
+	onRollback(ctx, func() { r.restore(series.ID, prev) })
+	return nil
+}
+
+func (r *inMemoryMorningCallSeriesRepository) Update(ctx context.Context, series *domain.MorningCallSeries) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.series[series.ID]
+	if !ok {
+		return apperrors.NotFoundError("morning call series").WithDetails("id", series.ID)
+	}
+
+	r.series[series.ID] = copySeries(series)
+	onRollback(ctx, func() { r.restore(series.ID, existing) })
+	return nil
+}
+
+func (r *inMemoryMorningCallSeriesRepository) ListActive(ctx context.Context) ([]*domain.MorningCallSeries, error) {
+	return r.list(func(s *domain.MorningCallSeries) bool {
+		return s.Status == domain.MorningCallSeriesStatusActive
+	}), nil
+}
+
+func (r *inMemoryMorningCallSeriesRepository) ListBySenderID(ctx context.Context, senderID domain.UserID) ([]*domain.MorningCallSeries, error) {
+	return r.list(func(s *domain.MorningCallSeries) bool {
+		return s.SenderID == senderID
+	}), nil
+}
+
+func (r *inMemoryMorningCallSeriesRepository) ListByReceiverID(ctx context.Context, receiverID domain.UserID) ([]*domain.MorningCallSeries, error) {
+	return r.list(func(s *domain.MorningCallSeries) bool {
+		return s.ReceiverID == receiverID
+	}), nil
+}
+
+func (r *inMemoryMorningCallSeriesRepository) list(match func(*domain.MorningCallSeries) bool) []*domain.MorningCallSeries {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.MorningCallSeries
+	for _, s := range r.series {
+		if match(s) {
+			result = append(result, copySeries(s))
+		}
+	}
+	return result
+}
+
+// restore はロールバック時に繰り返し設定を以前の状態に戻します。prev が nil の場合は削除します
+func (r *inMemoryMorningCallSeriesRepository) restore(id domain.MorningCallSeriesID, prev *domain.MorningCallSeries) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if prev == nil {
+		delete(r.series, id)
+		return
+	}
+	r.series[id] = prev
+}
+
+// copySeries は呼び出し側の変更がストアに影響しないようコピーを返します
+func copySeries(series *domain.MorningCallSeries) *domain.MorningCallSeries {
+	cp := *series
+	cp.Rule.ByDay = append([]time.Weekday(nil), series.Rule.ByDay...)
+	cp.Exceptions = append([]time.Time(nil), series.Exceptions...)
+	return &cp
+}
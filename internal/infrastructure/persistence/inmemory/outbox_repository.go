@@ -0,0 +1,118 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+)
+
+type inMemoryOutboxRepository struct {
+	mu          sync.RWMutex
+	records     []*domain.EventRecord // Offset の昇順
+	lastOffset  uint64
+	checkpoints map[string]uint64
+}
+
+func NewInMemoryOutboxRepository() repository.OutboxRepository {
+	return &inMemoryOutboxRepository{
+		checkpoints: make(map[string]uint64),
+	}
+}
+
+func (r *inMemoryOutboxRepository) Append(ctx context.Context, records ...*domain.EventRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prevLast := r.lastOffset
+	appended := make([]uint64, 0, len(records))
+	for _, record := range records {
+		// 読み込み時など既にオフセットを持つ場合はそのまま保存する
+		if record.Offset == 0 {
+			record.Offset = r.lastOffset + 1
+		}
+		r.lastOffset = max(r.lastOffset, record.Offset)
+
+		cp := *record
+		i, _ := slices.BinarySearchFunc(r.records, cp.Offset, func(e *domain.EventRecord, offset uint64) int {
+			return compareSeq(e.Offset, offset)
+		})
+		r.records = slices.Insert(r.records, i, &cp)
+		appended = append(appended, cp.Offset)
+	}
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.records = slices.DeleteFunc(r.records, func(e *domain.EventRecord) bool {
+			return slices.Contains(appended, e.Offset)
+		})
+		r.lastOffset = prevLast
+	})
+	return nil
+}
+
+func (r *inMemoryOutboxRepository) ListAfter(ctx context.Context, offset uint64, limit int) ([]*domain.EventRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, found := slices.BinarySearchFunc(r.records, offset, func(e *domain.EventRecord, offset uint64) int {
+		return compareSeq(e.Offset, offset)
+	})
+	if found {
+		i++
+	}
+
+	var result []*domain.EventRecord
+	for _, record := range r.records[i:] {
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		cp := *record
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+func (r *inMemoryOutboxRepository) FindCheckpoint(ctx context.Context, subscriber string) (uint64, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	offset, ok := r.checkpoints[subscriber]
+	return offset, ok, nil
+}
+
+func (r *inMemoryOutboxRepository) SaveCheckpoint(ctx context.Context, subscriber string, offset uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prev, existed := r.checkpoints[subscriber]
+	r.checkpoints[subscriber] = offset
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if existed {
+			r.checkpoints[subscriber] = prev
+		} else {
+			delete(r.checkpoints, subscriber)
+		}
+	})
+	return nil
+}
+
+// compareSeq は通し番号を比較します
+func compareSeq(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
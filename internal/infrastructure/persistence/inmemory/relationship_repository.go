@@ -0,0 +1,253 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// relationshipKey identifies a unidirectional relationship between two users
+type relationshipKey struct {
+	requesterID domain.UserID
+	receiverID  domain.UserID
+}
+
+// relationshipIDSet is a set of relationship IDs used for per-user indexes
+type relationshipIDSet = idSet[domain.RelationshipID]
+
+// inMemoryRelationshipRepository は RelationshipRepository のインメモリ実装です
+// 要求者・受信者ごとのインデックスを保持し、ユーザー単位の検索を全件走査せずに行います
+type inMemoryRelationshipRepository struct {
+	mu            sync.RWMutex
+	relationships map[domain.RelationshipID]*domain.Relationship
+	byPair        map[relationshipKey]domain.RelationshipID
+	byRequester   map[domain.UserID]relationshipIDSet
+	byReceiver    map[domain.UserID]relationshipIDSet
+}
+
+// NewInMemoryRelationshipRepository は新しい inMemoryRelationshipRepository を生成します
+func NewInMemoryRelationshipRepository() repository.RelationshipRepository {
+	return &inMemoryRelationshipRepository{
+		relationships: make(map[domain.RelationshipID]*domain.Relationship),
+		byPair:        make(map[relationshipKey]domain.RelationshipID),
+		byRequester:   make(map[domain.UserID]relationshipIDSet),
+		byReceiver:    make(map[domain.UserID]relationshipIDSet),
+	}
+}
+
+func (r *inMemoryRelationshipRepository) Create(ctx context.Context, relationship *domain.Relationship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !relationship.ID.IsValid() {
+		return apperrors.ValidationError("id", "relationship ID is invalid")
+	}
+	if _, ok := r.relationships[relationship.ID]; ok {
+		return apperrors.ConflictError("relationship").WithDetails("id", relationship.ID)
+	}
+	key := relationshipKey{requesterID: relationship.RequesterID, receiverID: relationship.ReceiverID}
+	if _, ok := r.byPair[key]; ok {
+		return apperrors.ConflictError("relationship").
+			WithDetails("requester_id", relationship.RequesterID).
+			WithDetails("receiver_id", relationship.ReceiverID)
+	}
+
+	// 新規作成時のバージョンは1。読み込み時など既にバージョンを持つ場合はそのまま保存する
+	if relationship.Version == 0 {
+		relationship.Version = 1
+	}
+	r.index(copyRelationship(relationship))
+	onRollback(ctx, func() { r.restore(relationship.ID, nil) })
+	return nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByID(ctx context.Context, id domain.RelationshipID) (*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	relationship, ok := r.relationships[id]
+	if !ok {
+		return nil, apperrors.NotFoundError("relationship").WithDetails("id", id)
+	}
+	return copyRelationship(relationship), nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByUsers(ctx context.Context, requesterID, receiverID domain.UserID) (*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byPair[relationshipKey{requesterID: requesterID, receiverID: receiverID}]
+	if !ok {
+		return nil, apperrors.NotFoundError("relationship").
+			WithDetails("requester_id", requesterID).
+			WithDetails("receiver_id", receiverID)
+	}
+	return copyRelationship(r.relationships[id]), nil
+}
+
+func (r *inMemoryRelationshipRepository) FindAllByUsers(ctx context.Context, user1ID, user2ID domain.UserID) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Relationship
+	for _, key := range []relationshipKey{
+		{requesterID: user1ID, receiverID: user2ID},
+		{requesterID: user2ID, receiverID: user1ID},
+	} {
+		if id, ok := r.byPair[key]; ok {
+			result = append(result, copyRelationship(r.relationships[id]))
+		}
+	}
+	return result, nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByUser(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := r.collect(r.byRequester[userID], nil)
+	return r.collect(r.byReceiver[userID], result), nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByUserWithStatus(ctx context.Context, userID domain.UserID, status domain.RelationshipStatus) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Relationship
+	for _, ids := range []relationshipIDSet{r.byRequester[userID], r.byReceiver[userID]} {
+		for id := range ids {
+			if rel := r.relationships[id]; rel.Status == status {
+				result = append(result, copyRelationship(rel))
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByStatus(ctx context.Context, status domain.RelationshipStatus) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Relationship
+	for _, rel := range r.relationships {
+		if rel.Status == status {
+			result = append(result, copyRelationship(rel))
+		}
+	}
+	return result, nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByRequester(ctx context.Context, requesterID domain.UserID) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.collect(r.byRequester[requesterID], nil), nil
+}
+
+func (r *inMemoryRelationshipRepository) FindByReceiver(ctx context.Context, receiverID domain.UserID) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.collect(r.byReceiver[receiverID], nil), nil
+}
+
+func (r *inMemoryRelationshipRepository) Update(ctx context.Context, relationship *domain.Relationship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.relationships[relationship.ID]
+	if !ok {
+		return apperrors.NotFoundError("relationship").WithDetails("id", relationship.ID)
+	}
+
+	// 取得後に他の処理で更新されていれば上書きしない
+	if existing.Version != relationship.Version {
+		return apperrors.VersionConflictError("relationship", relationship.Version, existing.Version).WithDetails("id", relationship.ID)
+	}
+
+	// 当事者が変わる場合は重複チェックを行う
+	key := relationshipKey{requesterID: relationship.RequesterID, receiverID: relationship.ReceiverID}
+	if id, ok := r.byPair[key]; ok && id != relationship.ID {
+		return apperrors.ConflictError("relationship").
+			WithDetails("requester_id", relationship.RequesterID).
+			WithDetails("receiver_id", relationship.ReceiverID)
+	}
+
+	relationship.Version++
+	r.unindex(existing)
+	r.index(copyRelationship(relationship))
+	onRollback(ctx, func() { r.restore(relationship.ID, existing) })
+	return nil
+}
+
+func (r *inMemoryRelationshipRepository) Delete(ctx context.Context, relationship *domain.Relationship) error {
+	return r.DeleteByID(ctx, relationship.ID)
+}
+
+func (r *inMemoryRelationshipRepository) DeleteByID(ctx context.Context, id domain.RelationshipID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.relationships[id]
+	if !ok {
+		return apperrors.NotFoundError("relationship").WithDetails("id", id)
+	}
+
+	r.unindex(existing)
+	onRollback(ctx, func() { r.restore(id, existing) })
+	return nil
+}
+
+func (r *inMemoryRelationshipRepository) ExistsByUsers(ctx context.Context, requesterID, receiverID domain.UserID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.byPair[relationshipKey{requesterID: requesterID, receiverID: receiverID}]
+	return ok, nil
+}
+
+// restore はロールバック時にリレーションシップを以前の状態に戻します。prev が nil の場合は削除します
+func (r *inMemoryRelationshipRepository) restore(id domain.RelationshipID, prev *domain.Relationship) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, ok := r.relationships[id]; ok {
+		r.unindex(current)
+	}
+	if prev != nil {
+		r.index(prev)
+	}
+}
+
+// index はリレーションシップを保存し、各インデックスに登録します
+func (r *inMemoryRelationshipRepository) index(rel *domain.Relationship) {
+	r.relationships[rel.ID] = rel
+	r.byPair[relationshipKey{requesterID: rel.RequesterID, receiverID: rel.ReceiverID}] = rel.ID
+	addToIDSet(r.byRequester, rel.RequesterID, rel.ID)
+	addToIDSet(r.byReceiver, rel.ReceiverID, rel.ID)
+}
+
+// unindex はリレーションシップを削除し、各インデックスから取り除きます
+func (r *inMemoryRelationshipRepository) unindex(rel *domain.Relationship) {
+	delete(r.relationships, rel.ID)
+	delete(r.byPair, relationshipKey{requesterID: rel.RequesterID, receiverID: rel.ReceiverID})
+	removeFromIDSet(r.byRequester, rel.RequesterID, rel.ID)
+	removeFromIDSet(r.byReceiver, rel.ReceiverID, rel.ID)
+}
+
+// collect はIDセットに含まれるリレーションシップのコピーを result に追加します
+func (r *inMemoryRelationshipRepository) collect(ids relationshipIDSet, result []*domain.Relationship) []*domain.Relationship {
+	for id := range ids {
+		result = append(result, copyRelationship(r.relationships[id]))
+	}
+	return result
+}
+
+// copyRelationship は呼び出し側の変更がストアに影響しないようコピーを返します
+func copyRelationship(rel *domain.Relationship) *domain.Relationship {
+	cp := *rel
+	return &cp
+}
@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// 取得後に他の更新があった場合は上書きせず、競合とする
+func TestRelationshipRepository_UpdateChecksVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryRelationshipRepository()
+	rel := domain.NewRelationship("alice", "bob", benchStart)
+	if err := repo.Create(ctx, rel); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	first, _ := repo.FindByID(ctx, rel.ID)
+	stale, _ := repo.FindByID(ctx, rel.ID)
+
+	first.Status = domain.RelationshipStatusApproved
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Version = %d, want 2 after the update", first.Version)
+	}
+
+	// 承認と同時に取り消された申請は、承認後の状態を上書きしない
+	stale.Status = domain.RelationshipStatusCancelled
+	if err := repo.Update(ctx, stale); !apperrors.IsConflictError(err) {
+		t.Errorf("Update with a stale version error = %v, want a conflict", err)
+	}
+	stored, _ := repo.FindByID(ctx, rel.ID)
+	if stored.Status != domain.RelationshipStatusApproved || stored.Version != 2 {
+		t.Errorf("stored = %s v%d, want approved v2", stored.Status, stored.Version)
+	}
+
+	stored.Status = domain.RelationshipStatusBlocked
+	if err := repo.Update(ctx, stored); err != nil || stored.Version != 3 {
+		t.Errorf("Update with the current version = v%d, %v, want v3, nil", stored.Version, err)
+	}
+}
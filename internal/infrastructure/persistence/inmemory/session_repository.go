@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// inMemorySessionRepository は SessionRepository のインメモリ実装です
+type inMemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*domain.Session
+}
+
+// NewInMemorySessionRepository は新しい inMemorySessionRepository を生成します
+func NewInMemorySessionRepository() repository.SessionRepository {
+	return &inMemorySessionRepository{
+		sessions: make(map[string]*domain.Session),
+	}
+}
+
+func (r *inMemorySessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[session.TokenHash]; ok {
+		return apperrors.ConflictError("session")
+	}
+	r.sessions[session.TokenHash] = session
+	onRollback(ctx, func() { r.restore(session.TokenHash, nil) })
+	return nil
+}
+
+func (r *inMemorySessionRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[tokenHash]
+	if !ok {
+		return nil, apperrors.NotFoundError("session")
+	}
+	return session, nil
+}
+
+func (r *inMemorySessionRepository) Delete(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.sessions[tokenHash]
+	if !ok {
+		return apperrors.NotFoundError("session")
+	}
+	delete(r.sessions, tokenHash)
+	onRollback(ctx, func() { r.restore(tokenHash, existing) })
+	return nil
+}
+
+// restore はロールバック時にセッションを以前の状態に戻します。prev が nil の場合は削除します
+func (r *inMemorySessionRepository) restore(tokenHash string, prev *domain.Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if prev == nil {
+		delete(r.sessions, tokenHash)
+		return
+	}
+	r.sessions[tokenHash] = prev
+}
@@ -0,0 +1,53 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"morning-call/internal/repository"
+)
+
+type txKey struct{}
+
+// inMemoryTx はトランザクション中の書き込みを取り消す処理を保持します
+type inMemoryTx struct {
+	undo []func()
+}
+
+// inMemoryTxManager は TxManager のインメモリ実装です
+// 書き込みはその場で反映し、エラー時は登録された取り消し処理を逆順に実行します。
+// トランザクション同士は直列化しますが、トランザクション外の読み取りからはコミット前の変更が見えます
+type inMemoryTxManager struct {
+	mu sync.Mutex
+}
+
+// NewInMemoryTxManager は新しい inMemoryTxManager を生成します
+func NewInMemoryTxManager() repository.TxManager {
+	return &inMemoryTxManager{}
+}
+
+func (m *inMemoryTxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 入れ子の場合は外側のトランザクションに参加する
+	if _, ok := ctx.Value(txKey{}).(*inMemoryTx); ok {
+		return fn(ctx)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx := &inMemoryTx{}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		return err
+	}
+	return nil
+}
+
+// onRollback は ctx がトランザクション内の場合に、ロールバック時の取り消し処理を登録します
+func onRollback(ctx context.Context, undo func()) {
+	if tx, ok := ctx.Value(txKey{}).(*inMemoryTx); ok {
+		tx.undo = append(tx.undo, undo)
+	}
+}
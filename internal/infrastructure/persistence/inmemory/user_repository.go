@@ -2,23 +2,27 @@ package inmemory
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	"morning-call/internal/domain"
 	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/shared/validation"
 )
 
 // inMemoryUserRepository は UserRepository のインメモリ実装です
+// 正規化したメールアドレスのインデックスを保持し、メールアドレスでの検索を全件走査せずに行います
 type inMemoryUserRepository struct {
-	mu    sync.RWMutex
-	users map[domain.UserID]*domain.User
+	mu      sync.RWMutex
+	users   map[domain.UserID]*domain.User
+	byEmail map[string]domain.UserID
 }
 
 // NewInMemoryUserRepository は新しい inMemoryUserRepository を生成します
 func NewInMemoryUserRepository() repository.UserRepository {
 	return &inMemoryUserRepository{
-		users: make(map[domain.UserID]*domain.User),
+		users:   make(map[domain.UserID]*domain.User),
+		byEmail: make(map[string]domain.UserID),
 	}
 }
 
@@ -28,9 +32,9 @@ func (r *inMemoryUserRepository) FindByID(ctx context.Context, id domain.UserID)
 
 	user, ok := r.users[id]
 	if !ok {
-		return nil, fmt.Errorf("user not found: %s", id)
+		return nil, apperrors.NotFoundError("user").WithDetails("id", id)
 	}
-	return user, nil
+	return copyUser(user), nil
 }
 
 func (r *inMemoryUserRepository) Create(ctx context.Context, user *domain.User) error {
@@ -38,9 +42,18 @@ func (r *inMemoryUserRepository) Create(ctx context.Context, user *domain.User)
 	defer r.mu.Unlock()
 
 	if _, ok := r.users[user.ID]; ok {
-		return fmt.Errorf("user already exists: %s", user.ID)
+		return apperrors.ConflictError("user").WithDetails("id", user.ID)
 	}
-	r.users[user.ID] = user
+	if r.emailTaken(user) {
+		return apperrors.ConflictError("email")
+	}
+
+	// 新規作成時のバージョンは1。読み込み時など既にバージョンを持つ場合はそのまま保存する
+	if user.Version == 0 {
+		user.Version = 1
+	}
+	r.index(copyUser(user))
+	onRollback(ctx, func() { r.restore(user.ID, nil) })
 	return nil
 }
 
@@ -48,22 +61,45 @@ func (r *inMemoryUserRepository) FindByEmail(ctx context.Context, email string)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	id, ok := r.byEmail[validation.NormalizeEmail(email)]
+	if !ok {
+		return nil, apperrors.NotFoundError("user").WithDetails("email", email)
+	}
+	return copyUser(r.users[id]), nil
+}
+
+func (r *inMemoryUserRepository) FindAll(ctx context.Context) ([]*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.User, 0, len(r.users))
 	for _, user := range r.users {
-		if user.Email == email {
-			return user, nil
-		}
+		result = append(result, copyUser(user))
 	}
-	return nil, fmt.Errorf("user not found with email: %s", email)
+	return result, nil
 }
 
 func (r *inMemoryUserRepository) Update(ctx context.Context, user *domain.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.users[user.ID]; !ok {
-		return fmt.Errorf("user not found: %s", user.ID)
+	existing, ok := r.users[user.ID]
+	if !ok {
+		return apperrors.NotFoundError("user").WithDetails("id", user.ID)
 	}
-	r.users[user.ID] = user
+
+	// 取得後に他の処理で更新されていれば上書きしない
+	if existing.Version != user.Version {
+		return apperrors.VersionConflictError("user", user.Version, existing.Version).WithDetails("id", user.ID)
+	}
+	if r.emailTaken(user) {
+		return apperrors.ConflictError("email")
+	}
+
+	user.Version++
+	r.unindex(existing)
+	r.index(copyUser(user))
+	onRollback(ctx, func() { r.restore(user.ID, existing) })
 	return nil
 }
 
@@ -73,8 +109,55 @@ func (r *inMemoryUserRepository) UpdateRelatedUsers(ctx context.Context, userID
 
 	user, ok := r.users[userID]
 	if !ok {
-		return fmt.Errorf("user not found: %s", userID)
+		return apperrors.NotFoundError("user").WithDetails("id", userID)
 	}
-	user.RelatedUsers = relatedUsers
+	updated := copyUser(user)
+	updated.RelatedUsers = append([]domain.RelatedUser(nil), relatedUsers...)
+	updated.Version++
+	r.users[userID] = updated
+	onRollback(ctx, func() { r.restore(userID, user) })
 	return nil
 }
+
+// restore はロールバック時にユーザーを以前の状態に戻します。prev が nil の場合は削除します
+func (r *inMemoryUserRepository) restore(id domain.UserID, prev *domain.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, ok := r.users[id]; ok {
+		r.unindex(current)
+	}
+	if prev != nil {
+		r.index(prev)
+	}
+}
+
+// index はユーザーを保存し、メールアドレスのインデックスに登録します
+func (r *inMemoryUserRepository) index(user *domain.User) {
+	r.users[user.ID] = user
+	r.byEmail[validation.NormalizeEmail(user.Email)] = user.ID
+}
+
+// unindex はユーザーを削除し、メールアドレスのインデックスから取り除きます
+func (r *inMemoryUserRepository) unindex(user *domain.User) {
+	delete(r.users, user.ID)
+	delete(r.byEmail, validation.NormalizeEmail(user.Email))
+}
+
+// emailTaken は同じメールアドレス（正規化後）を別のユーザーが使っているかを返します
+func (r *inMemoryUserRepository) emailTaken(user *domain.User) bool {
+	id, ok := r.byEmail[validation.NormalizeEmail(user.Email)]
+	return ok && id != user.ID
+}
+
+// copyUser は呼び出し側の変更がストアに影響しないようコピーを返します
+// スライスも複製し、append による共有を防ぎます
+func copyUser(user *domain.User) *domain.User {
+	cp := *user
+	cp.Notification.Channels = append([]domain.NotificationChannel(nil), user.Notification.Channels...)
+	cp.Preferences.AllowedCallers = append([]domain.UserID(nil), user.Preferences.AllowedCallers...)
+	cp.Preferences.DeniedCallers = append([]domain.UserID(nil), user.Preferences.DeniedCallers...)
+	cp.MorningCalls = append([]domain.MorningCall(nil), user.MorningCalls...)
+	cp.RelatedUsers = append([]domain.RelatedUser(nil), user.RelatedUsers...)
+	return &cp
+}
@@ -0,0 +1,97 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// benchUsers はベンチマークのユーザー数です
+const benchUsers = 10_000
+
+func benchUserID(i int) domain.UserID {
+	return domain.UserID(fmt.Sprintf("user-%05d", i))
+}
+
+func benchEmail(i int) string {
+	return fmt.Sprintf("user-%05d@example.com", i)
+}
+
+var (
+	benchUserRepoOnce sync.Once
+	benchUserRepo     repository.UserRepository
+)
+
+// userRepositoryFixture は benchUsers 人を登録したリポジトリを返します。ベンチマーク間で共有します
+func userRepositoryFixture(b *testing.B) repository.UserRepository {
+	b.Helper()
+
+	benchUserRepoOnce.Do(func() {
+		ctx := context.Background()
+		repo := NewInMemoryUserRepository()
+		for i := range benchUsers {
+			user := &domain.User{ID: benchUserID(i), Username: fmt.Sprintf("user%05d", i), Email: benchEmail(i)}
+			if err := repo.Create(ctx, user); err != nil {
+				b.Fatalf("Create: %v", err)
+			}
+		}
+		benchUserRepo = repo
+	})
+	return benchUserRepo
+}
+
+func BenchmarkFindByEmail(b *testing.B) {
+	repo := userRepositoryFixture(b)
+	ctx := context.Background()
+
+	emails := make([]string, benchUsers)
+	for i := range emails {
+		emails[i] = benchEmail(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.FindByEmail(ctx, emails[i%benchUsers]); err != nil {
+			b.Fatalf("FindByEmail: %v", err)
+		}
+	}
+}
+
+// 取得後に他の更新があった場合は上書きせず、競合とする
+func TestUserRepository_UpdateChecksVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryUserRepository()
+	if err := repo.Create(ctx, &domain.User{ID: "alice", Username: "alice", Email: "alice@example.com"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	first, _ := repo.FindByID(ctx, "alice")
+	stale, _ := repo.FindByID(ctx, "alice")
+
+	first.TimeZone = "Asia/Tokyo"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Version = %d, want 2 after the update", first.Version)
+	}
+
+	stale.TimeZone = "UTC"
+	if err := repo.Update(ctx, stale); !apperrors.IsConflictError(err) {
+		t.Errorf("Update with a stale version error = %v, want a conflict", err)
+	}
+	stored, _ := repo.FindByID(ctx, "alice")
+	if stored.TimeZone != "Asia/Tokyo" || stored.Version != 2 {
+		t.Errorf("stored = %s v%d, want Asia/Tokyo v2", stored.TimeZone, stored.Version)
+	}
+
+	stored.TimeZone = "UTC"
+	if err := repo.Update(ctx, stored); err != nil || stored.Version != 3 {
+		t.Errorf("Update with the current version = v%d, %v, want v3, nil", stored.Version, err)
+	}
+}
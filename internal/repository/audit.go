@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+)
+
+// AuditRepository is append-only storage for the audit log
+type AuditRepository interface {
+	// Append assigns the next sequence number to the entry and stores it
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+	// ListByUser returns up to limit entries the user acted in or was the target of, newest first
+	ListByUser(ctx context.Context, userID domain.UserID, limit int) ([]*domain.AuditEntry, error)
+	// ListByEntity returns up to limit entries about the entity, newest first
+	ListByEntity(ctx context.Context, entityID string, limit int) ([]*domain.AuditEntry, error)
+}
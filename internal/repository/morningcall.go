@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"morning-call/internal/domain"
+	"time"
 )
 
 type MorningCallRepository interface {
@@ -12,4 +13,12 @@ type MorningCallRepository interface {
 	Delete(ctx context.Context, id domain.MorningCallID) error
 	ListBySenderID(ctx context.Context, senderID domain.UserID) ([]*domain.MorningCall, error)
 	ListByReceiverID(ctx context.Context, receiverID domain.UserID) ([]*domain.MorningCall, error)
+	// ListByReceiverBetween returns the receiver's morning calls of any status whose Time is in [from, to)
+	ListByReceiverBetween(ctx context.Context, receiverID domain.UserID, from, to time.Time) ([]*domain.MorningCall, error)
+	// ListDueBefore returns scheduled or snoozed morning calls whose FireTime is not after the given time
+	ListDueBefore(ctx context.Context, t time.Time) ([]*domain.MorningCall, error)
+	ListByStatus(ctx context.Context, status domain.MorningCallStatus) ([]*domain.MorningCall, error)
+	ListBySeriesID(ctx context.Context, seriesID domain.MorningCallSeriesID) ([]*domain.MorningCall, error)
+	// Search returns the morning calls matching the query in its sort order, at most query.Limit of them
+	Search(ctx context.Context, query domain.MorningCallQuery) ([]*domain.MorningCall, error)
 }
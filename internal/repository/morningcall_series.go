@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+)
+
+type MorningCallSeriesRepository interface {
+	FindByID(ctx context.Context, id domain.MorningCallSeriesID) (*domain.MorningCallSeries, error)
+	Save(ctx context.Context, series *domain.MorningCallSeries) error
+	Update(ctx context.Context, series *domain.MorningCallSeries) error
+	ListActive(ctx context.Context) ([]*domain.MorningCallSeries, error)
+	ListBySenderID(ctx context.Context, senderID domain.UserID) ([]*domain.MorningCallSeries, error)
+	ListByReceiverID(ctx context.Context, receiverID domain.UserID) ([]*domain.MorningCallSeries, error)
+}
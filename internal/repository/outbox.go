@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+)
+
+// OutboxRepository stores domain events written in the same transaction as the state change that raised them
+type OutboxRepository interface {
+	// Append assigns the next offsets to the records and stores them
+	Append(ctx context.Context, records ...*domain.EventRecord) error
+	// ListAfter returns up to limit records with an offset greater than the given one, in offset order
+	ListAfter(ctx context.Context, offset uint64, limit int) ([]*domain.EventRecord, error)
+	// FindCheckpoint returns the last offset the subscriber has processed
+	FindCheckpoint(ctx context.Context, subscriber string) (uint64, bool, error)
+	SaveCheckpoint(ctx context.Context, subscriber string, offset uint64) error
+}
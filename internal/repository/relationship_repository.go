@@ -29,6 +29,9 @@ type RelationshipRepository interface {
 	// Returns relationships where the user is involved and has the specified status
 	FindByUserWithStatus(ctx context.Context, userID domain.UserID, status domain.RelationshipStatus) ([]*domain.Relationship, error)
 
+	// FindByStatus finds all relationships with a specific status
+	FindByStatus(ctx context.Context, status domain.RelationshipStatus) ([]*domain.Relationship, error)
+
 	// FindByRequester finds all relationships initiated by a specific user
 	FindByRequester(ctx context.Context, requesterID domain.UserID) ([]*domain.Relationship, error)
 
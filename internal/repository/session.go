@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"morning-call/internal/domain"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*domain.Session, error)
+	Delete(ctx context.Context, tokenHash string) error
+}
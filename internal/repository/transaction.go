@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+// TxManager runs several repository writes as a single unit of work
+// Writes made with the context passed to fn are committed together when fn
+// returns nil and rolled back when it returns an error. Nested calls join the
+// outer transaction. Inside fn, always pass the given context to repositories:
+// a write with an outer context is not part of the transaction and may block on it.
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
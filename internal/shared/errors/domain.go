@@ -14,6 +14,9 @@ const (
 	// ErrorTypeValidation indicates a validation error
 	ErrorTypeValidation ErrorType = "VALIDATION"
 
+	// ErrorTypeAuthentication indicates the caller could not be authenticated
+	ErrorTypeAuthentication ErrorType = "AUTHENTICATION"
+
 	// ErrorTypeAuthorization indicates an authorization error
 	ErrorTypeAuthorization ErrorType = "AUTHORIZATION"
 
@@ -73,6 +76,16 @@ func ValidationError(field, reason string) *DomainError {
 	).WithDetails("field", field).WithDetails("reason", reason)
 }
 
+// ReasonError creates an error of the given type whose message is a validation reason
+func ReasonError(errType ErrorType, reason string) *DomainError {
+	return NewDomainError(errType, reason).WithDetails("reason", reason)
+}
+
+// AuthenticationError creates an authentication error
+func AuthenticationError(reason string) *DomainError {
+	return ReasonError(ErrorTypeAuthentication, reason)
+}
+
 // AuthorizationError creates an authorization error
 func AuthorizationError(action string) *DomainError {
 	return NewDomainError(
@@ -89,6 +102,16 @@ func ConflictError(resource string) *DomainError {
 	).WithDetails("resource", resource)
 }
 
+// VersionConflictError creates a conflict error for an update based on a stale version
+func VersionConflictError(resource string, expected, current int) *DomainError {
+	return NewDomainError(
+		ErrorTypeConflict,
+		fmt.Sprintf("%s was modified by another request", resource),
+	).WithDetails("resource", resource).
+		WithDetails("expected_version", expected).
+		WithDetails("current_version", current)
+}
+
 // InternalError creates an internal error
 func InternalError(message string) *DomainError {
 	return NewDomainError(ErrorTypeInternal, message)
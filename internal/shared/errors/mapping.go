@@ -1,17 +1,29 @@
 package errors
 
 import (
+	stderrors "errors"
 	"net/http"
 )
 
+// asDomainError finds the first DomainError in the error chain
+func asDomainError(err error) (*DomainError, bool) {
+	var domainErr *DomainError
+	if stderrors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
 // HTTPStatusFromError maps a domain error to an HTTP status code
 func HTTPStatusFromError(err error) int {
-	if domainErr, ok := err.(*DomainError); ok {
+	if domainErr, ok := asDomainError(err); ok {
 		switch domainErr.Type {
 		case ErrorTypeNotFound:
 			return http.StatusNotFound
 		case ErrorTypeValidation:
 			return http.StatusBadRequest
+		case ErrorTypeAuthentication:
+			return http.StatusUnauthorized
 		case ErrorTypeAuthorization:
 			return http.StatusForbidden
 		case ErrorTypeConflict:
@@ -38,7 +50,7 @@ type ErrorResponse struct {
 
 // ToErrorResponse converts a domain error to an API error response
 func ToErrorResponse(err error) *ErrorResponse {
-	if domainErr, ok := err.(*DomainError); ok {
+	if domainErr, ok := asDomainError(err); ok {
 		return &ErrorResponse{
 			Error:   string(domainErr.Type),
 			Message: domainErr.Message,
@@ -56,32 +68,32 @@ func ToErrorResponse(err error) *ErrorResponse {
 
 // IsNotFoundError checks if an error is a not found error
 func IsNotFoundError(err error) bool {
-	if domainErr, ok := err.(*DomainError); ok {
-		return domainErr.Type == ErrorTypeNotFound
-	}
-	return false
+	return isType(err, ErrorTypeNotFound)
 }
 
 // IsValidationError checks if an error is a validation error
 func IsValidationError(err error) bool {
-	if domainErr, ok := err.(*DomainError); ok {
-		return domainErr.Type == ErrorTypeValidation
-	}
-	return false
+	return isType(err, ErrorTypeValidation)
+}
+
+// IsAuthenticationError checks if an error is an authentication error
+func IsAuthenticationError(err error) bool {
+	return isType(err, ErrorTypeAuthentication)
 }
 
 // IsAuthorizationError checks if an error is an authorization error
 func IsAuthorizationError(err error) bool {
-	if domainErr, ok := err.(*DomainError); ok {
-		return domainErr.Type == ErrorTypeAuthorization
-	}
-	return false
+	return isType(err, ErrorTypeAuthorization)
 }
 
 // IsConflictError checks if an error is a conflict error
 func IsConflictError(err error) bool {
-	if domainErr, ok := err.(*DomainError); ok {
-		return domainErr.Type == ErrorTypeConflict
+	return isType(err, ErrorTypeConflict)
+}
+
+func isType(err error, errType ErrorType) bool {
+	if domainErr, ok := asDomainError(err); ok {
+		return domainErr.Type == errType
 	}
 	return false
 }
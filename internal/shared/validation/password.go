@@ -0,0 +1,12 @@
+package validation
+
+import (
+	"unicode/utf8"
+)
+
+// ValidatePassword validates password length
+func ValidatePassword(password string) bool {
+	// Check length (min 8, max 128 characters)
+	length := utf8.RuneCountInString(password)
+	return length >= 8 && length <= 128
+}
@@ -4,28 +4,29 @@ import (
 	"time"
 )
 
-// ValidateScheduledTime validates if the scheduled time is valid for a morning call
-func ValidateScheduledTime(scheduledTime time.Time) bool {
-	now := time.Now()
+// MaxScheduleDaysAhead is how many calendar days ahead a single morning call can be scheduled
+const MaxScheduleDaysAhead = 30
 
+// ScheduleHorizon returns the latest time a morning call can be scheduled for at now
+func ScheduleHorizon(now time.Time) time.Time {
+	return now.AddDate(0, 0, MaxScheduleDaysAhead)
+}
+
+// ValidateScheduledTime validates if the scheduled time is valid for a morning call at now
+// The hour window is evaluated in loc, which should be the receiver's time zone.
+func ValidateScheduledTime(scheduledTime, now time.Time, loc *time.Location) bool {
 	// Cannot schedule in the past
 	if scheduledTime.Before(now) {
 		return false
 	}
 
-	// Cannot schedule more than 1 year in advance
-	oneYearFromNow := now.AddDate(1, 0, 0)
-	if scheduledTime.After(oneYearFromNow) {
+	// Cannot schedule beyond the horizon
+	if scheduledTime.After(ScheduleHorizon(now)) {
 		return false
 	}
 
 	// Check if time is within reasonable morning call hours (4:00 AM - 12:00 PM)
-	hour := scheduledTime.Hour()
-	if hour < 4 || hour >= 12 {
-		return false
-	}
-
-	return true
+	return IsMorningCallTime(scheduledTime, loc)
 }
 
 // ValidateDuration validates if a duration is within acceptable range
@@ -37,16 +38,25 @@ func ValidateDuration(duration time.Duration) bool {
 	return true
 }
 
-// IsBusinessHours checks if the given time is within business hours
-func IsBusinessHours(t time.Time) bool {
-	hour := t.Hour()
+// ValidateTimeZone validates if the name is a loadable IANA time zone such as "Asia/Tokyo"
+func ValidateTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// IsBusinessHours checks if the given time is within business hours in loc
+func IsBusinessHours(t time.Time, loc *time.Location) bool {
+	hour := t.In(loc).Hour()
 	// Business hours: 9:00 AM - 6:00 PM
 	return hour >= 9 && hour < 18
 }
 
-// IsMorningCallTime checks if the given time is appropriate for morning calls
-func IsMorningCallTime(t time.Time) bool {
-	hour := t.Hour()
+// IsMorningCallTime checks if the given time is appropriate for morning calls in loc
+func IsMorningCallTime(t time.Time, loc *time.Location) bool {
+	hour := t.In(loc).Hour()
 	// Morning call hours: 4:00 AM - 12:00 PM
 	return hour >= 4 && hour < 12
 }
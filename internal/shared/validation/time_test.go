@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%s): %v", name, err)
+	}
+	return loc
+}
+
+func TestIsMorningCallTime(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		t    time.Time
+		want bool
+	}{
+		{"tokyo 07:00", "Asia/Tokyo", time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC), true},
+		{"tokyo 04:00", "Asia/Tokyo", time.Date(2026, 10, 18, 19, 0, 0, 0, time.UTC), true},
+		{"tokyo 03:59", "Asia/Tokyo", time.Date(2026, 10, 18, 18, 59, 0, 0, time.UTC), false},
+		{"tokyo 11:59", "Asia/Tokyo", time.Date(2026, 10, 19, 2, 59, 0, 0, time.UTC), true},
+		{"tokyo 12:00", "Asia/Tokyo", time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC), false},
+		// UTC の 07:00 は東京では 16:00
+		{"tokyo 16:00", "Asia/Tokyo", time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), false},
+
+		{"utc 04:00", "UTC", time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC), true},
+		{"utc 03:59", "UTC", time.Date(2026, 10, 19, 3, 59, 0, 0, time.UTC), false},
+		{"utc 12:00", "UTC", time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), false},
+
+		// 2026-11-01 02:00 EDT に夏時間が終わる。同じ UTC 時刻でも前日と現地時刻が1時間ずれる
+		{"new york 04:30 EDT before fall back", "America/New_York", time.Date(2026, 10, 31, 8, 30, 0, 0, time.UTC), true},
+		{"new york 03:30 EST after fall back", "America/New_York", time.Date(2026, 11, 1, 8, 30, 0, 0, time.UTC), false},
+		{"new york 11:30 EDT before fall back", "America/New_York", time.Date(2026, 10, 31, 15, 30, 0, 0, time.UTC), true},
+		{"new york 11:30 EST after fall back", "America/New_York", time.Date(2026, 11, 1, 16, 30, 0, 0, time.UTC), true},
+		// 2026-03-08 02:00 EST に夏時間が始まる
+		{"new york 03:30 EST before spring forward", "America/New_York", time.Date(2026, 3, 7, 8, 30, 0, 0, time.UTC), false},
+		{"new york 04:30 EDT after spring forward", "America/New_York", time.Date(2026, 3, 8, 8, 30, 0, 0, time.UTC), true},
+		{"new york 11:00 EST before spring forward", "America/New_York", time.Date(2026, 3, 7, 16, 0, 0, 0, time.UTC), true},
+		{"new york 12:00 EDT after spring forward", "America/New_York", time.Date(2026, 3, 8, 16, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMorningCallTime(tt.t, mustLoadLocation(t, tt.zone)); got != tt.want {
+				t.Errorf("IsMorningCallTime(%v, %s) = %v, want %v", tt.t, tt.zone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateScheduledTime(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		now  time.Time
+		t    time.Time
+		want bool
+	}{
+		{"tokyo tomorrow 07:00", "Asia/Tokyo", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC), true},
+		{"tokyo now", "Asia/Tokyo", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), true},
+		{"tokyo past", "Asia/Tokyo", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), false},
+		{"tokyo at horizon", "Asia/Tokyo", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 18, 0, 0, 0, 0, time.UTC), true},
+		{"tokyo beyond horizon", "Asia/Tokyo", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 18, 0, 0, 1, 0, time.UTC), false},
+		{"tokyo evening", "Asia/Tokyo", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC), false},
+
+		{"utc 07:00", "UTC", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), true},
+		{"utc 13:00", "UTC", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC), false},
+
+		// 11:00Z は夏時間の終わる前は 07:00 EDT、終わった後は 06:00 EST
+		{"new york 07:00 EDT", "America/New_York", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 31, 11, 0, 0, 0, time.UTC), true},
+		{"new york 06:00 EST", "America/New_York", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 11, 0, 0, 0, time.UTC), true},
+		// 夏時間の始まる前は UTC-5、始まった後は UTC-4 で判定する
+		{"new york 08:30 EST", "America/New_York", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 7, 13, 30, 0, 0, time.UTC), true},
+		{"new york 12:30 EDT", "America/New_York", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 8, 16, 30, 0, 0, time.UTC), false},
+		// 03:30 EST は時間帯外
+		{"new york 03:30 EST", "America/New_York", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 8, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateScheduledTime(tt.t, tt.now, mustLoadLocation(t, tt.zone)); got != tt.want {
+				t.Errorf("ValidateScheduledTime(%v, %v, %s) = %v, want %v", tt.t, tt.now, tt.zone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleHorizonKeepsWallClockAcrossDST(t *testing.T) {
+	loc := mustLoadLocation(t, "America/New_York")
+
+	// 夏時間の終わりをまたいでも、上限は同じ現地時刻になる
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, loc)
+	horizon := ScheduleHorizon(now)
+	if want := time.Date(2026, 11, 18, 7, 0, 0, 0, loc); !horizon.Equal(want) {
+		t.Errorf("ScheduleHorizon(%v) = %v, want %v", now, horizon, want)
+	}
+}
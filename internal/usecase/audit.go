@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+const (
+	// defaultAuditLimit は件数の指定がない場合に返す監査ログの件数です
+	defaultAuditLimit = 100
+	// maxAuditLimit は一度に取得できる監査ログの最大件数です
+	maxAuditLimit = 1000
+)
+
+type auditUsecase struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditUsecase(auditRepo repository.AuditRepository) AuditUsecase {
+	return &auditUsecase{
+		auditRepo: auditRepo,
+	}
+}
+
+func (rcv *auditUsecase) ListByUser(ctx context.Context, userID domain.UserID, limit int) ([]*domain.AuditEntry, error) {
+	limit, err := auditLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	return rcv.auditRepo.ListByUser(ctx, userID, limit)
+}
+
+func (rcv *auditUsecase) ListByEntity(ctx context.Context, entityID string, limit int) ([]*domain.AuditEntry, error) {
+	limit, err := auditLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	return rcv.auditRepo.ListByEntity(ctx, entityID, limit)
+}
+
+func auditLimit(limit int) (int, error) {
+	switch {
+	case limit == 0:
+		return defaultAuditLimit, nil
+	case limit < 0 || limit > maxAuditLimit:
+		return 0, apperrors.ValidationError("limit", domain.NGReasonInvalidParameter.String())
+	default:
+		return limit, nil
+	}
+}
+
+// recordAudit は監査ログを1件追記します
+// 状態の変更と同じトランザクション内で呼び出し、変更と記録をまとめて確定させます
+func recordAudit(ctx context.Context, auditRepo repository.AuditRepository, entry domain.AuditEntry) error {
+	return auditRepo.Append(ctx, &entry)
+}
+
+// userAudit はユーザー自身の操作の監査ログを生成します
+func userAudit(user *domain.User, action domain.AuditAction, now time.Time) domain.AuditEntry {
+	return domain.AuditEntry{
+		ActorID:       user.ID,
+		Action:        action,
+		EntityType:    domain.AuditEntityUser,
+		EntityID:      string(user.ID),
+		TargetUserIDs: []domain.UserID{user.ID},
+		OccurredAt:    now,
+	}
+}
+
+// relationshipAudit はリレーションシップに対する操作の監査ログを生成します
+func relationshipAudit(actorID domain.UserID, action domain.AuditAction, relationship *domain.Relationship, before domain.RelationshipStatus) domain.AuditEntry {
+	return domain.AuditEntry{
+		ActorID:       actorID,
+		Action:        action,
+		EntityType:    domain.AuditEntityRelationship,
+		EntityID:      relationship.ID.String(),
+		TargetUserIDs: []domain.UserID{relationship.RequesterID, relationship.ReceiverID},
+		BeforeStatus:  string(before),
+		AfterStatus:   string(relationship.Status),
+		OccurredAt:    relationship.UpdatedAt,
+	}
+}
+
+// seriesAudit は繰り返し設定に対する操作の監査ログを生成します
+func seriesAudit(actorID domain.UserID, action domain.AuditAction, series *domain.MorningCallSeries, before domain.MorningCallSeriesStatus, now time.Time) domain.AuditEntry {
+	return domain.AuditEntry{
+		ActorID:       actorID,
+		Action:        action,
+		EntityType:    domain.AuditEntitySeries,
+		EntityID:      string(series.ID),
+		TargetUserIDs: []domain.UserID{series.SenderID, series.ReceiverID},
+		BeforeStatus:  string(before),
+		AfterStatus:   string(series.Status),
+		OccurredAt:    now,
+	}
+}
+
+// morningCallAudit はモーニングコールに対する操作の監査ログを生成します
+func morningCallAudit(actorID domain.UserID, action domain.AuditAction, morningCall *domain.MorningCall, before domain.MorningCallStatus, now time.Time) domain.AuditEntry {
+	return domain.AuditEntry{
+		ActorID:       actorID,
+		Action:        action,
+		EntityType:    domain.AuditEntityMorningCall,
+		EntityID:      string(morningCall.ID),
+		TargetUserIDs: []domain.UserID{morningCall.SenderID, morningCall.ReceiverID},
+		BeforeStatus:  string(before),
+		AfterStatus:   string(morningCall.Status),
+		OccurredAt:    now,
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	"morning-call/internal/shared/auth"
+)
+
+type authUsecase struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	sessionTTL  time.Duration
+	now         func() time.Time
+}
+
+func NewAuthUsecase(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, sessionTTL time.Duration) AuthUsecase {
+	return &authUsecase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		sessionTTL:  sessionTTL,
+		now:         time.Now,
+	}
+}
+
+// dummyPasswordHash はユーザーが存在しない場合にも同じコストで検証するためのハッシュ
+// 計算のコストが高いため、最初に必要になった時点で一度だけ生成する
+var dummyPasswordHash = sync.OnceValues(func() (string, error) {
+	return auth.HashPassword("morning-call-dummy-password")
+})
+
+func (a *authUsecase) Login(ctx context.Context, email, password string) (string, *domain.Session, error) {
+	user, _ := a.userRepo.FindByEmail(ctx, email)
+
+	// ユーザーの有無で応答時間が変わらないよう、常にハッシュ検証を行う
+	var passwordHash string
+	if user != nil {
+		passwordHash = user.PasswordHash
+	} else {
+		hash, err := dummyPasswordHash()
+		if err != nil {
+			return "", nil, err
+		}
+		passwordHash = hash
+	}
+	if !auth.VerifyPassword(password, passwordHash) || user == nil {
+		return "", nil, ngReasonError(domain.NGReasonInvalidCredentials)
+	}
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := a.now()
+	session := &domain.Session{
+		TokenHash: auth.HashToken(token),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(a.sessionTTL),
+	}
+
+	if err := a.sessionRepo.Create(ctx, session); err != nil {
+		return "", nil, err
+	}
+
+	return token, session, nil
+}
+
+func (a *authUsecase) Logout(ctx context.Context, token string) error {
+	return a.sessionRepo.Delete(ctx, auth.HashToken(token))
+}
+
+func (a *authUsecase) Authenticate(ctx context.Context, token string) (domain.UserID, error) {
+	tokenHash := auth.HashToken(token)
+
+	session, err := a.sessionRepo.FindByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return "", ngReasonError(domain.NGReasonInvalidCredentials)
+	}
+
+	// 期限切れのセッションは削除する
+	if session.IsExpired(a.now()) {
+		_ = a.sessionRepo.Delete(ctx, tokenHash)
+		return "", ngReasonError(domain.NGReasonSessionExpired)
+	}
+
+	return session.UserID, nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	"morning-call/internal/shared/clock"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// Deliverer はモーニングコールを受信者に届ける手段です
+type Deliverer interface {
+	Deliver(ctx context.Context, morningCall *domain.MorningCall) error
+}
+
+// defaultDeliveryTimeout は1件の配信に許容する時間です
+const defaultDeliveryTimeout = 10 * time.Second
+
+type dispatchUsecase struct {
+	morningCallRepo repository.MorningCallRepository
+	outboxRepo      repository.OutboxRepository
+	auditRepo       repository.AuditRepository
+	txManager       repository.TxManager
+	deliverer       Deliverer
+	clock           clock.Clock
+	deliveryTimeout time.Duration
+	ackWindow       time.Duration
+}
+
+// NewDispatchUsecase は配信処理を生成します
+// ackWindow は配信後に受信者の応答を待つ時間で、過ぎると失敗として扱います
+func NewDispatchUsecase(morningCallRepo repository.MorningCallRepository, outboxRepo repository.OutboxRepository, auditRepo repository.AuditRepository, txManager repository.TxManager, deliverer Deliverer, clk clock.Clock, ackWindow time.Duration) DispatchUsecase {
+	return &dispatchUsecase{
+		morningCallRepo: morningCallRepo,
+		outboxRepo:      outboxRepo,
+		auditRepo:       auditRepo,
+		txManager:       txManager,
+		deliverer:       deliverer,
+		clock:           clk,
+		deliveryTimeout: defaultDeliveryTimeout,
+		ackWindow:       ackWindow,
+	}
+}
+
+// DispatchDue は予定時刻を過ぎたモーニングコールを配信し、結果に応じてステータスを更新します
+func (rcv *dispatchUsecase) DispatchDue(ctx context.Context) error {
+	now := rcv.clock.Now()
+
+	due, err := rcv.morningCallRepo.ListDueBefore(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	// 配信時刻の早い順に配信する
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].FireTime().Before(due[j].FireTime())
+	})
+
+	var errs []error
+	for _, morningCall := range due {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := rcv.dispatch(ctx, morningCall, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (rcv *dispatchUsecase) dispatch(ctx context.Context, morningCall *domain.MorningCall, now time.Time) error {
+	// 取得後に更新・削除された場合は対象外
+	if ng := morningCall.CanComplete(now); ng.IsNG() {
+		return nil
+	}
+
+	deliverCtx, cancel := context.WithTimeout(ctx, rcv.deliveryTimeout)
+	deliverErr := rcv.deliverer.Deliver(deliverCtx, morningCall)
+	cancel()
+
+	if deliverErr != nil {
+		morningCall.Status = domain.MorningCallStatusFailed
+	} else {
+		// 配信できたら受信者の応答を待つ
+		morningCall.Status = domain.MorningCallStatusDelivered
+		morningCall.DeliveredAt = now
+		morningCall.AckDeadline = now.Add(rcv.ackWindow)
+	}
+
+	err := rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+			return err
+		}
+		if deliverErr != nil {
+			return nil
+		}
+		return recordEvents(ctx, rcv.outboxRepo, now, domain.MorningCallFired{
+			MorningCallID: morningCall.ID,
+			SenderID:      morningCall.SenderID,
+			ReceiverID:    morningCall.ReceiverID,
+			DeliveredAt:   morningCall.DeliveredAt,
+			AckDeadline:   morningCall.AckDeadline,
+		})
+	})
+	if err != nil {
+		// 配信中に送信者が編集・削除した場合は上書きせず、次回の実行で改めて判定する
+		return fmt.Errorf("failed to record dispatch of morning call %s: %w", morningCall.ID, err)
+	}
+
+	if deliverErr != nil {
+		return fmt.Errorf("failed to deliver morning call %s: %w", morningCall.ID, deliverErr)
+	}
+	return nil
+}
+
+// ExpireUnacknowledged は応答期限を過ぎても応答のないモーニングコールを失敗にします
+func (rcv *dispatchUsecase) ExpireUnacknowledged(ctx context.Context) error {
+	now := rcv.clock.Now()
+
+	delivered, err := rcv.morningCallRepo.ListByStatus(ctx, domain.MorningCallStatusDelivered)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, morningCall := range delivered {
+		if !morningCall.IsAckOverdue(now) {
+			continue
+		}
+
+		morningCall.Status = domain.MorningCallStatusFailed
+		err := rcv.txManager.Do(ctx, func(ctx context.Context) error {
+			if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+				return err
+			}
+			entry := morningCallAudit(domain.SystemActor, domain.AuditActionMorningCallExpired, morningCall, domain.MorningCallStatusDelivered, now)
+			return recordAudit(ctx, rcv.auditRepo, entry)
+		})
+		if err != nil {
+			// 取得後に受信者が応答・スヌーズした場合はそちらを優先する
+			if apperrors.IsConflictError(err) {
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
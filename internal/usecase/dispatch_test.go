@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+)
+
+// fakeDeliverer は配信を記録し、deliver が設定されていればその結果を返します
+type fakeDeliverer struct {
+	delivered []domain.MorningCallID
+	deliver   func(ctx context.Context, morningCall *domain.MorningCall) error
+}
+
+func (d *fakeDeliverer) Deliver(ctx context.Context, morningCall *domain.MorningCall) error {
+	d.delivered = append(d.delivered, morningCall.ID)
+	if d.deliver == nil {
+		return nil
+	}
+	return d.deliver(ctx, morningCall)
+}
+
+func TestDispatchDue_DeliversOnlyWhenDue(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 6, 59, 0, 0, time.UTC)
+	env := newTestEnv(start)
+	deliverer := &fakeDeliverer{}
+	dispatcher := env.dispatchUsecase(deliverer)
+	env.saveMorningCall(t, "mc-1", start.Add(time.Minute))
+
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue before the scheduled time: %v", err)
+	}
+	if len(deliverer.delivered) != 0 {
+		t.Fatalf("delivered %v before the scheduled time", deliverer.delivered)
+	}
+	if got := env.findMorningCall(t, "mc-1").Status; got != domain.MorningCallStatusScheduled {
+		t.Fatalf("status before the scheduled time = %s, want %s", got, domain.MorningCallStatusScheduled)
+	}
+
+	env.clock.Advance(time.Minute)
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue at the scheduled time: %v", err)
+	}
+
+	got := env.findMorningCall(t, "mc-1")
+	if got.Status != domain.MorningCallStatusDelivered {
+		t.Fatalf("status = %s, want %s", got.Status, domain.MorningCallStatusDelivered)
+	}
+	if now := env.clock.Now(); !got.DeliveredAt.Equal(now) || !got.AckDeadline.Equal(now.Add(testAckWindow)) {
+		t.Errorf("DeliveredAt, AckDeadline = %v, %v, want %v, %v", got.DeliveredAt, got.AckDeadline, now, now.Add(testAckWindow))
+	}
+
+	events := env.events(t)
+	if len(events) != 1 || events[0].Type != domain.EventTypeMorningCallFired {
+		t.Errorf("events = %v, want one %s", events, domain.EventTypeMorningCallFired)
+	}
+
+	// 配信済みのモーニングコールは再配信しない
+	env.clock.Advance(time.Minute)
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue after delivery: %v", err)
+	}
+	if len(deliverer.delivered) != 1 {
+		t.Errorf("delivered %v, want one delivery", deliverer.delivered)
+	}
+}
+
+func TestDispatchDue_DeliversInFireTimeOrder(t *testing.T) {
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(start)
+	deliverer := &fakeDeliverer{}
+	dispatcher := env.dispatchUsecase(deliverer)
+	env.saveMorningCall(t, "later", start.Add(-time.Minute))
+	env.saveMorningCall(t, "earlier", start.Add(-2*time.Minute))
+
+	if err := dispatcher.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+
+	want := []domain.MorningCallID{"earlier", "later"}
+	if len(deliverer.delivered) != len(want) || deliverer.delivered[0] != want[0] || deliverer.delivered[1] != want[1] {
+		t.Errorf("delivered %v, want %v", deliverer.delivered, want)
+	}
+}
+
+func TestDispatchDue_FailedDeliveryIsNotRetried(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(start)
+	deliverer := &fakeDeliverer{}
+	dispatcher := env.dispatchUsecase(deliverer)
+	env.saveMorningCall(t, "mc-1", start)
+
+	errUnreachable := errors.New("receiver unreachable")
+	deliverer.deliver = func(context.Context, *domain.MorningCall) error {
+		return errUnreachable
+	}
+
+	if err := dispatcher.DispatchDue(ctx); !errors.Is(err, errUnreachable) {
+		t.Fatalf("DispatchDue error = %v, want %v", err, errUnreachable)
+	}
+	if got := env.findMorningCall(t, "mc-1").Status; got != domain.MorningCallStatusFailed {
+		t.Fatalf("status = %s, want %s", got, domain.MorningCallStatusFailed)
+	}
+	if events := env.events(t); len(events) != 0 {
+		t.Errorf("events = %v, want none for a failed delivery", events)
+	}
+
+	env.clock.Advance(time.Minute)
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue after failure: %v", err)
+	}
+	if len(deliverer.delivered) != 1 {
+		t.Errorf("delivered %v, want the failed call to be tried once", deliverer.delivered)
+	}
+}
+
+func TestDispatchDue_RetriesCallEditedDuringDelivery(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(start)
+	deliverer := &fakeDeliverer{}
+	dispatcher := env.dispatchUsecase(deliverer)
+	env.saveMorningCall(t, "mc-1", start)
+
+	// 配信中に送信者がメッセージを編集する
+	deliverer.deliver = func(ctx context.Context, morningCall *domain.MorningCall) error {
+		edited := env.findMorningCall(t, morningCall.ID)
+		edited.Message = "edited"
+		return env.morningCallRepo.Update(ctx, edited)
+	}
+
+	if err := dispatcher.DispatchDue(ctx); err == nil {
+		t.Fatal("DispatchDue error = nil, want the version conflict")
+	}
+	got := env.findMorningCall(t, "mc-1")
+	if got.Status != domain.MorningCallStatusScheduled || got.Message != "edited" {
+		t.Fatalf("status, message = %s, %q, want the edit to be kept", got.Status, got.Message)
+	}
+	if events := env.events(t); len(events) != 0 {
+		t.Errorf("events = %v, want none for the rolled back dispatch", events)
+	}
+
+	// 編集後の内容で次回の実行時に改めて配信する
+	deliverer.deliver = nil
+	env.clock.Advance(time.Minute)
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue retry: %v", err)
+	}
+	if got := env.findMorningCall(t, "mc-1"); got.Status != domain.MorningCallStatusDelivered || got.Message != "edited" {
+		t.Errorf("status, message = %s, %q, want the edited call delivered", got.Status, got.Message)
+	}
+	if len(deliverer.delivered) != 2 {
+		t.Errorf("delivered %v, want two attempts", deliverer.delivered)
+	}
+}
+
+func TestDispatchDue_RedeliversSnoozedCall(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(start)
+	deliverer := &fakeDeliverer{}
+	dispatcher := env.dispatchUsecase(deliverer)
+	env.saveMorningCall(t, "mc-1", start)
+
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+
+	snoozed := env.findMorningCall(t, "mc-1")
+	snoozed.Status = domain.MorningCallStatusSnoozed
+	snoozed.SnoozedUntil = start.Add(10 * time.Minute)
+	if err := env.morningCallRepo.Update(ctx, snoozed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	env.clock.Advance(9 * time.Minute)
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue while snoozed: %v", err)
+	}
+	if got := env.findMorningCall(t, "mc-1").Status; got != domain.MorningCallStatusSnoozed {
+		t.Fatalf("status while snoozed = %s, want %s", got, domain.MorningCallStatusSnoozed)
+	}
+
+	env.clock.Advance(time.Minute)
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue after snooze: %v", err)
+	}
+	got := env.findMorningCall(t, "mc-1")
+	if got.Status != domain.MorningCallStatusDelivered || !got.AckDeadline.Equal(env.clock.Now().Add(testAckWindow)) {
+		t.Errorf("status, AckDeadline = %s, %v, want redelivery with a new deadline", got.Status, got.AckDeadline)
+	}
+}
+
+func TestExpireUnacknowledged(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(start)
+	deliverer := &fakeDeliverer{}
+	dispatcher := env.dispatchUsecase(deliverer)
+	env.saveMorningCall(t, "ignored", start)
+	env.saveMorningCall(t, "acknowledged", start)
+
+	if err := dispatcher.DispatchDue(ctx); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+
+	acknowledged := env.findMorningCall(t, "acknowledged")
+	acknowledged.Status = domain.MorningCallStatusAcknowledged
+	acknowledged.AcknowledgedAt = start.Add(time.Minute)
+	if err := env.morningCallRepo.Update(ctx, acknowledged); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	// 応答期限ちょうどはまだ失敗にしない
+	env.clock.Advance(testAckWindow)
+	if err := dispatcher.ExpireUnacknowledged(ctx); err != nil {
+		t.Fatalf("ExpireUnacknowledged at the deadline: %v", err)
+	}
+	if got := env.findMorningCall(t, "ignored").Status; got != domain.MorningCallStatusDelivered {
+		t.Fatalf("status at the deadline = %s, want %s", got, domain.MorningCallStatusDelivered)
+	}
+
+	env.clock.Advance(time.Second)
+	if err := dispatcher.ExpireUnacknowledged(ctx); err != nil {
+		t.Fatalf("ExpireUnacknowledged after the deadline: %v", err)
+	}
+	if got := env.findMorningCall(t, "ignored").Status; got != domain.MorningCallStatusFailed {
+		t.Errorf("ignored status = %s, want %s", got, domain.MorningCallStatusFailed)
+	}
+	if got := env.findMorningCall(t, "acknowledged").Status; got != domain.MorningCallStatusAcknowledged {
+		t.Errorf("acknowledged status = %s, want %s", got, domain.MorningCallStatusAcknowledged)
+	}
+
+	// 期限切れは応答のなかったものだけ監査ログに残す
+	entries, err := env.auditRepo.ListByEntity(ctx, "ignored", 10)
+	if err != nil {
+		t.Fatalf("ListByEntity: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Action != domain.AuditActionMorningCallExpired || entries[0].ActorID != domain.SystemActor ||
+		entries[0].BeforeStatus != string(domain.MorningCallStatusDelivered) || entries[0].AfterStatus != string(domain.MorningCallStatusFailed) {
+		t.Errorf("ignored audit entries = %v, want one delivered -> failed expiry", entries)
+	}
+	if entries, err := env.auditRepo.ListByEntity(ctx, "acknowledged", 10); err != nil || len(entries) != 0 {
+		t.Errorf("acknowledged audit entries = %v, %v, want none", entries, err)
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"errors"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// ngReasonError はNGReasonを対応する種別のDomainErrorに変換します
+func ngReasonError(ng domain.NGReason) error {
+	return apperrors.ReasonError(ngReasonErrorType(ng), ng.String())
+}
+
+// ngReasonErrorType はNGReasonからエラー種別を決定します
+func ngReasonErrorType(ng domain.NGReason) apperrors.ErrorType {
+	switch ng {
+	case domain.NGReasonUserNotFound,
+		domain.NGReasonMorningCallNotFound:
+		return apperrors.ErrorTypeNotFound
+
+	case domain.NGReasonNotFriend,
+		domain.NGReasonBlocked,
+		domain.NGReasonBlockedByUser,
+		domain.NGReasonNoPermission,
+		domain.NGReasonNotSender,
+		domain.NGReasonNotReceiver,
+		domain.NGReasonCallerNotAllowed:
+		return apperrors.ErrorTypeAuthorization
+
+	case domain.NGReasonAlreadyFriend,
+		domain.NGReasonAlreadyRequested,
+		domain.NGReasonPendingRequest,
+		domain.NGReasonNotBlocked,
+		domain.NGReasonNoPendingRequest,
+		domain.NGReasonAlreadyCompleted,
+		domain.NGReasonAlreadyDeleted,
+		domain.NGReasonNotDeleted,
+		domain.NGReasonRestoreExpired,
+		domain.NGReasonDuplicateSchedule,
+		domain.NGReasonScheduleTooClose,
+		domain.NGReasonNotDelivered,
+		domain.NGReasonAlreadyAcknowledged,
+		domain.NGReasonAckDeadlinePassed,
+		domain.NGReasonSnoozeLimitReached,
+		domain.NGReasonDailyLimitReached:
+		return apperrors.ErrorTypeConflict
+
+	case domain.NGReasonInvalidCredentials,
+		domain.NGReasonSessionExpired:
+		return apperrors.ErrorTypeAuthentication
+
+	default:
+		return apperrors.ErrorTypeValidation
+	}
+}
+
+// relationshipError はリレーションシップのドメインエラーを対応する種別のDomainErrorに変換します
+func relationshipError(err error) error {
+	var errType apperrors.ErrorType
+	switch {
+	case errors.Is(err, domain.ErrRelationshipNotFound):
+		errType = apperrors.ErrorTypeNotFound
+	case errors.Is(err, domain.ErrUserBlocked),
+		errors.Is(err, domain.ErrNotReceiver),
+		errors.Is(err, domain.ErrNotFriends):
+		errType = apperrors.ErrorTypeAuthorization
+	case errors.Is(err, domain.ErrAlreadyFriends),
+		errors.Is(err, domain.ErrAlreadyRequested),
+		errors.Is(err, domain.ErrInvalidRelationshipStatus):
+		errType = apperrors.ErrorTypeConflict
+	case errors.Is(err, domain.ErrCannotRequestSelf),
+		errors.Is(err, domain.ErrCannotBlockSelf):
+		errType = apperrors.ErrorTypeValidation
+	default:
+		return err
+	}
+	return apperrors.ReasonError(errType, err.Error())
+}
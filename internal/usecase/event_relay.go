@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+)
+
+// relayBatchSize は1回の読み込みで購読者に配送するイベントの件数です
+const relayBatchSize = 100
+
+// EventHandler はアウトボックスから配送されたイベントを処理します
+// エラーを返すと処理済みにせず、次回の配送で同じイベントから再送します
+type EventHandler func(ctx context.Context, record *domain.EventRecord) error
+
+type subscription struct {
+	name       string
+	fromOffset uint64
+	handler    EventHandler
+}
+
+type eventRelay struct {
+	outboxRepo repository.OutboxRepository
+	txManager  repository.TxManager
+
+	mu            sync.Mutex // 配送と巻き戻しを直列化する
+	subscriptions []subscription
+}
+
+// NewEventRelay はアウトボックスのイベントをプロセス内の購読者に配送するリレーを生成します
+// 購読者ごとに処理済みのオフセットをチェックポイントとして保存し、少なくとも1回の配送を保証します
+func NewEventRelay(outboxRepo repository.OutboxRepository, txManager repository.TxManager) EventRelay {
+	return &eventRelay{
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+	}
+}
+
+func (rcv *eventRelay) Subscribe(name string, fromOffset uint64, handler EventHandler) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	rcv.subscriptions = append(rcv.subscriptions, subscription{name: name, fromOffset: fromOffset, handler: handler})
+}
+
+func (rcv *eventRelay) Rewind(ctx context.Context, name string, offset uint64) error {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	for _, sub := range rcv.subscriptions {
+		if sub.name == name {
+			return rcv.outboxRepo.SaveCheckpoint(ctx, name, offset)
+		}
+	}
+	return fmt.Errorf("unknown event subscriber %q", name)
+}
+
+// RelayPending は各購読者にチェックポイント以降のイベントを順番に配送します
+func (rcv *eventRelay) RelayPending(ctx context.Context) error {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	var errs []error
+	for _, sub := range rcv.subscriptions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := rcv.relay(ctx, sub); err != nil {
+			errs = append(errs, fmt.Errorf("failed to relay events to %s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (rcv *eventRelay) relay(ctx context.Context, sub subscription) error {
+	offset, ok, err := rcv.outboxRepo.FindCheckpoint(ctx, sub.name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		offset = sub.fromOffset
+	}
+
+	for {
+		records, err := rcv.listCommitted(ctx, offset)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		// 失敗したイベントで止め、順序を保ったまま次回そこから再送する
+		delivered := offset
+		var handlerErr error
+		for _, record := range records {
+			if handlerErr = sub.handler(ctx, record); handlerErr != nil {
+				handlerErr = fmt.Errorf("event %d (%s): %w", record.Offset, record.Type, handlerErr)
+				break
+			}
+			delivered = record.Offset
+		}
+
+		if delivered != offset {
+			if err := rcv.outboxRepo.SaveCheckpoint(ctx, sub.name, delivered); err != nil {
+				return errors.Join(handlerErr, err)
+			}
+			offset = delivered
+		}
+		if handlerErr != nil || len(records) < relayBatchSize {
+			return handlerErr
+		}
+	}
+}
+
+// listCommitted はオフセット以降のイベントを読み込みます
+// トランザクションは直列化されるため、トランザクション内で読むことでコミット前のイベントを配送しないようにします
+func (rcv *eventRelay) listCommitted(ctx context.Context, offset uint64) ([]*domain.EventRecord, error) {
+	var records []*domain.EventRecord
+	err := rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		records, err = rcv.outboxRepo.ListAfter(ctx, offset, relayBatchSize)
+		return err
+	})
+	return records, err
+}
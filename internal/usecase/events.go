@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+)
+
+// recordEvents はドメインイベントをアウトボックスに保存します
+// 状態の変更と同じトランザクション内で呼び出し、変更とイベントをまとめて確定させます
+func recordEvents(ctx context.Context, outboxRepo repository.OutboxRepository, now time.Time, events ...domain.Event) error {
+	records := make([]*domain.EventRecord, 0, len(events))
+	for _, event := range events {
+		record, err := domain.NewEventRecord(event, now)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	return outboxRepo.Append(ctx, records...)
+}
+
+// morningCallScheduled は設定したモーニングコールのイベントを生成します
+func morningCallScheduled(mc *domain.MorningCall) domain.MorningCallScheduled {
+	return domain.MorningCallScheduled{
+		MorningCallID: mc.ID,
+		SenderID:      mc.SenderID,
+		ReceiverID:    mc.ReceiverID,
+		Time:          mc.Time,
+		SeriesID:      mc.SeriesID,
+	}
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/infrastructure/persistence/inmemory"
+	"morning-call/internal/repository"
+	"morning-call/internal/shared/clock"
+)
+
+const (
+	testAckWindow     = 5 * time.Minute
+	testSeriesHorizon = 10 * 24 * time.Hour
+)
+
+// testEnv はインメモリのリポジトリと手動の時計をまとめたもので、各 usecase はこれを共有して組み立てます
+type testEnv struct {
+	clock            *clock.Manual
+	userRepo         repository.UserRepository
+	relationshipRepo repository.RelationshipRepository
+	morningCallRepo  *slowMorningCallRepository
+	seriesRepo       *slowSeriesRepository
+	outboxRepo       repository.OutboxRepository
+	auditRepo        repository.AuditRepository
+	txManager        repository.TxManager
+}
+
+func newTestEnv(now time.Time) *testEnv {
+	return &testEnv{
+		clock:            clock.NewManual(now),
+		userRepo:         inmemory.NewInMemoryUserRepository(),
+		relationshipRepo: inmemory.NewInMemoryRelationshipRepository(),
+		morningCallRepo:  &slowMorningCallRepository{MorningCallRepository: inmemory.NewInMemoryMorningCallRepository()},
+		seriesRepo:       &slowSeriesRepository{MorningCallSeriesRepository: inmemory.NewInMemoryMorningCallSeriesRepository()},
+		outboxRepo:       inmemory.NewInMemoryOutboxRepository(),
+		auditRepo:        inmemory.NewInMemoryAuditRepository(),
+		txManager:        inmemory.NewInMemoryTxManager(),
+	}
+}
+
+func (e *testEnv) userUsecase() UserUsecase {
+	return NewUserUsecase(e.userRepo, e.relationshipRepo, e.morningCallRepo, e.seriesRepo, e.outboxRepo, e.auditRepo, e.txManager, e.clock)
+}
+
+func (e *testEnv) morningCallUsecase() MorningCallUsecase {
+	return NewMorningCallUsecase(e.morningCallRepo, e.seriesRepo, e.userRepo, e.relationshipRepo, e.outboxRepo, e.auditRepo, e.txManager, e.clock)
+}
+
+func (e *testEnv) seriesUsecase() MorningCallSeriesUsecase {
+	return NewMorningCallSeriesUsecase(e.seriesRepo, e.morningCallRepo, e.userRepo, e.relationshipRepo, e.outboxRepo, e.txManager, e.clock, testSeriesHorizon)
+}
+
+func (e *testEnv) dispatchUsecase(deliverer Deliverer) DispatchUsecase {
+	return NewDispatchUsecase(e.morningCallRepo, e.outboxRepo, e.auditRepo, e.txManager, deliverer, e.clock, testAckWindow)
+}
+
+// deliverer はログを w に書き出す通知の配信処理を組み立てます
+func (e *testEnv) deliverer(w io.Writer, notifiers ...Notifier) Deliverer {
+	return NewNotificationDeliverer(e.userRepo, slog.New(slog.NewTextHandler(w, nil)), notifiers...)
+}
+
+func (e *testEnv) createUser(t *testing.T, user *domain.User) {
+	t.Helper()
+
+	if user.Username == "" {
+		user.Username = string(user.ID)
+	}
+	if user.Email == "" {
+		user.Email = string(user.ID) + "@example.com"
+	}
+	if err := e.userRepo.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create(%s): %v", user.ID, err)
+	}
+}
+
+func (e *testEnv) befriend(t *testing.T, requesterID, receiverID domain.UserID) {
+	t.Helper()
+
+	rel := domain.NewRelationship(requesterID, receiverID, e.clock.Now())
+	if err := rel.Approve(e.clock.Now()); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if err := e.relationshipRepo.Create(context.Background(), rel); err != nil {
+		t.Fatalf("Create relationship: %v", err)
+	}
+}
+
+// addFriends は UTC のフレンド同士 sender と receiver を作成します
+func (e *testEnv) addFriends(t *testing.T, prefs domain.ReceiverPreferences) {
+	t.Helper()
+
+	e.createUser(t, &domain.User{ID: "sender", TimeZone: "UTC"})
+	e.createUser(t, &domain.User{ID: "receiver", TimeZone: "UTC", Preferences: prefs})
+	e.befriend(t, "sender", "receiver")
+}
+
+// saveMorningCall は sender から receiver 宛ての予定済みのモーニングコールを直接保存します
+func (e *testEnv) saveMorningCall(t *testing.T, id domain.MorningCallID, at time.Time) {
+	t.Helper()
+
+	err := e.morningCallRepo.Save(context.Background(), &domain.MorningCall{
+		ID:         id,
+		SenderID:   "sender",
+		ReceiverID: "receiver",
+		Time:       at,
+		Status:     domain.MorningCallStatusScheduled,
+	})
+	if err != nil {
+		t.Fatalf("Save(%s): %v", id, err)
+	}
+}
+
+func (e *testEnv) findMorningCall(t *testing.T, id domain.MorningCallID) *domain.MorningCall {
+	t.Helper()
+
+	morningCall, err := e.morningCallRepo.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID(%s): %v", id, err)
+	}
+	return morningCall
+}
+
+func (e *testEnv) events(t *testing.T) []*domain.EventRecord {
+	t.Helper()
+
+	records, err := e.outboxRepo.ListAfter(context.Background(), 0, 100)
+	if err != nil {
+		t.Fatalf("ListAfter: %v", err)
+	}
+	return records
+}
+
+// slowMorningCallRepository と slowSeriesRepository は読み込みを遅らせ、同時に更新した場合の競合を再現しやすくします
+type slowMorningCallRepository struct {
+	repository.MorningCallRepository
+	listDelay time.Duration
+}
+
+func (r *slowMorningCallRepository) ListByReceiverBetween(ctx context.Context, receiverID domain.UserID, from, to time.Time) ([]*domain.MorningCall, error) {
+	time.Sleep(r.listDelay)
+	return r.MorningCallRepository.ListByReceiverBetween(ctx, receiverID, from, to)
+}
+
+type slowSeriesRepository struct {
+	repository.MorningCallSeriesRepository
+	findDelay time.Duration
+}
+
+func (r *slowSeriesRepository) FindByID(ctx context.Context, id domain.MorningCallSeriesID) (*domain.MorningCallSeries, error) {
+	// 読み込んだ後に遅らせ、読み込みから更新までの間に他の更新が入るようにする
+	series, err := r.MorningCallSeriesRepository.FindByID(ctx, id)
+	time.Sleep(r.findDelay)
+	return series, err
+}
@@ -3,23 +3,104 @@ package usecase
 import (
 	"context"
 	"morning-call/internal/domain"
+	"time"
 )
 
 // UserUsecase defines the interface for user-related use cases
 type UserUsecase interface {
-	Register(ctx context.Context, username, email string) (*domain.User, error)
-	Login(ctx context.Context, email string) (*domain.User, error)
+	Register(ctx context.Context, username, email, password, timeZone string) (*domain.User, error)
 	ListFriends(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error)
 	ApplyFriend(ctx context.Context, userID, targetUserID domain.UserID) error
 	ReactFriendApply(ctx context.Context, userID, applyingUserID domain.UserID, approve bool) (domain.RelatedUserStatus, error)
 	BlockFriend(ctx context.Context, userID, blockUserID domain.UserID) error
+	UnblockUser(ctx context.Context, userID, blockedUserID domain.UserID) error
+	// CancelFriendRequest and Unfriend end the relationship and cancel the pending morning calls between the two users
+	CancelFriendRequest(ctx context.Context, userID, targetUserID domain.UserID) error
+	Unfriend(ctx context.Context, userID, friendID domain.UserID) error
+	// ExpireFriendRequests expires the friend requests left pending for ttl or longer
+	ExpireFriendRequests(ctx context.Context, ttl time.Duration) error
+	ListBlockedUsers(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error)
+	// ListFriendRequests returns the users whose friend requests to userID are awaiting approval
+	ListFriendRequests(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error)
+	GetUser(ctx context.Context, userID domain.UserID) (*domain.User, error)
+	// Update methods take the version the client last saw; 0 skips the precondition
+	UpdateNotificationSettings(ctx context.Context, userID domain.UserID, settings domain.NotificationSettings, version int) (*domain.User, error)
+	UpdateTimeZone(ctx context.Context, userID domain.UserID, timeZone string, version int) (*domain.User, error)
+	UpdateReceiverPreferences(ctx context.Context, userID domain.UserID, prefs domain.ReceiverPreferences, version int) (*domain.User, error)
+}
+
+// RelationshipUsecase defines the interface for relationship use cases built on domain.Relationship.
+// It is only reached through UserUsecase, which adds the morning call cleanup and audit entries.
+type RelationshipUsecase interface {
+	SendRequest(ctx context.Context, requesterID, receiverID domain.UserID) (*domain.Relationship, error)
+	Approve(ctx context.Context, userID domain.UserID, relationshipID domain.RelationshipID) (*domain.Relationship, error)
+	Reject(ctx context.Context, userID domain.UserID, relationshipID domain.RelationshipID) (*domain.Relationship, error)
+	Block(ctx context.Context, userID, targetUserID domain.UserID) (*domain.Relationship, error)
+	Unblock(ctx context.Context, userID, targetUserID domain.UserID) (*domain.Relationship, error)
+	CancelRequest(ctx context.Context, userID, targetUserID domain.UserID) (*domain.Relationship, error)
+	Unfriend(ctx context.Context, userID, friendID domain.UserID) (*domain.Relationship, error)
+	ListBlocked(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error)
+	ListFriends(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error)
+	ListPendingRequests(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error)
+}
+
+// AuthUsecase defines the interface for authentication use cases
+type AuthUsecase interface {
+	Login(ctx context.Context, email, password string) (string, *domain.Session, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (domain.UserID, error)
 }
 
 // MorningCallUsecase defines the interface for morning call-related use cases
 type MorningCallUsecase interface {
 	SaveFriendMorningCall(ctx context.Context, userID, friendID domain.UserID, morningCall *domain.MorningCall) error
 	GetFriendMorningCall(ctx context.Context, userID, friendID domain.UserID, morningCallID domain.MorningCallID) (*domain.MorningCall, error)
-	ListMorningCalls(ctx context.Context, userID domain.UserID) ([]*domain.MorningCall, error)
+	// ListMorningCalls returns one page of the user's morning calls and the cursor of the next page ("" on the last page)
+	ListMorningCalls(ctx context.Context, userID domain.UserID, query domain.MorningCallQuery, cursor string) ([]*domain.MorningCall, string, error)
+	// UpdateMorningCall uses morningCall.Version as the expected version; 0 skips the precondition
 	UpdateMorningCall(ctx context.Context, userID domain.UserID, morningCall *domain.MorningCall) error
-	DeleteMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID) error
+	// DeleteMorningCall marks the call as deleted; it can be restored within domain.RestoreWindow
+	DeleteMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID, version int) error
+	RestoreMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID) (*domain.MorningCall, error)
+	AcknowledgeMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID) (*domain.MorningCall, error)
+	SnoozeMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID, duration time.Duration) (*domain.MorningCall, error)
+	// PurgeDeleted permanently removes morning calls deleted at least retention ago
+	PurgeDeleted(ctx context.Context, retention time.Duration) error
+}
+
+// MorningCallSeriesUsecase defines the interface for recurring morning call use cases
+type MorningCallSeriesUsecase interface {
+	CreateSeries(ctx context.Context, userID, friendID domain.UserID, series *domain.MorningCallSeries) error
+	GetSeries(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID) (*domain.MorningCallSeries, error)
+	ListSeries(ctx context.Context, userID domain.UserID) ([]*domain.MorningCallSeries, error)
+	ListOccurrences(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, from, to time.Time) ([]time.Time, error)
+	SkipOccurrence(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, occurrence time.Time) error
+	UpdateFollowing(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, occurrence time.Time, changes *domain.MorningCallSeries) (*domain.MorningCallSeries, error)
+	CancelFollowing(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, occurrence time.Time) error
+	MaterializeDue(ctx context.Context) error
+}
+
+// DispatchUsecase defines the interface for firing morning calls whose time has come
+type DispatchUsecase interface {
+	DispatchDue(ctx context.Context) error
+	ExpireUnacknowledged(ctx context.Context) error
+}
+
+// EventRelay delivers domain events from the outbox to in-process subscribers
+type EventRelay interface {
+	// Subscribe registers a handler. A new subscriber starts after fromOffset;
+	// one with a saved checkpoint resumes from it.
+	Subscribe(name string, fromOffset uint64, handler EventHandler)
+	// Rewind makes the subscriber receive the events after offset again
+	Rewind(ctx context.Context, name string, offset uint64) error
+	// RelayPending delivers new events to every subscriber in offset order
+	RelayPending(ctx context.Context) error
+}
+
+// AuditUsecase defines the interface for querying the audit log
+type AuditUsecase interface {
+	// ListByUser returns the entries the user acted in or was affected by, newest first
+	ListByUser(ctx context.Context, userID domain.UserID, limit int) ([]*domain.AuditEntry, error)
+	// ListByEntity returns the entries about a user, relationship or morning call, newest first
+	ListByEntity(ctx context.Context, entityID string, limit int) ([]*domain.AuditEntry, error)
 }
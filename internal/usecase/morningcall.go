@@ -2,38 +2,164 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"morning-call/internal/domain"
 	"morning-call/internal/repository"
+	"morning-call/internal/shared/clock"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/shared/validation"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type morningCallUsecase struct {
-	morningCallRepo repository.MorningCallRepository
-	userRepo        repository.UserRepository
+	morningCallRepo  repository.MorningCallRepository
+	seriesRepo       repository.MorningCallSeriesRepository
+	userRepo         repository.UserRepository
+	relationshipRepo repository.RelationshipRepository
+	outboxRepo       repository.OutboxRepository
+	auditRepo        repository.AuditRepository
+	txManager        repository.TxManager
+	clock            clock.Clock
 }
 
-func NewMorningCallUsecase(morningCallRepo repository.MorningCallRepository, userRepo repository.UserRepository) MorningCallUsecase {
+func NewMorningCallUsecase(morningCallRepo repository.MorningCallRepository, seriesRepo repository.MorningCallSeriesRepository, userRepo repository.UserRepository, relationshipRepo repository.RelationshipRepository, outboxRepo repository.OutboxRepository, auditRepo repository.AuditRepository, txManager repository.TxManager, clk clock.Clock) MorningCallUsecase {
 	return &morningCallUsecase{
-		morningCallRepo: morningCallRepo,
-		userRepo:        userRepo,
+		morningCallRepo:  morningCallRepo,
+		seriesRepo:       seriesRepo,
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		outboxRepo:       outboxRepo,
+		auditRepo:        auditRepo,
+		txManager:        txManager,
+		clock:            clk,
 	}
 }
 
+// checkCanAcceptMorningCall は受信者が送信者からのモーニングコールを受け付けるかを確認し、受信者を返します
+func checkCanAcceptMorningCall(ctx context.Context, userRepo repository.UserRepository, relationshipRepo repository.RelationshipRepository, senderID, receiverID domain.UserID) (*domain.User, error) {
+	receiver, err := userRepo.FindByID(ctx, receiverID)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships, err := relationshipRepo.FindAllByUsers(ctx, receiverID, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ng := receiver.CanAcceptMorningCall(senderID, relationships); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+	return receiver, nil
+}
+
+// checkReceiverPreferences は受信者の受け付け設定に照らして、送信者から時刻 t のモーニングコールを受け付けるかを確認します
+// 更新の場合は excludeID に更新対象を指定し、1日の件数や重複の判定に含めないようにします
+func checkReceiverPreferences(ctx context.Context, morningCallRepo repository.MorningCallRepository, receiver *domain.User, senderID domain.UserID, t time.Time, excludeID domain.MorningCallID) error {
+	ng, err := receiverPreferencesNG(ctx, morningCallRepo, receiver, senderID, t, excludeID)
+	if err != nil {
+		return err
+	}
+	if ng.IsNG() {
+		return ngReasonError(ng)
+	}
+	return nil
+}
+
+// receiverPreferencesNG は checkReceiverPreferences の判定結果を NGReason で返します
+// 受け付けない発生分を読み飛ばす繰り返し設定の生成でも使います
+func receiverPreferencesNG(ctx context.Context, morningCallRepo repository.MorningCallRepository, receiver *domain.User, senderID domain.UserID, t time.Time, excludeID domain.MorningCallID) (domain.NGReason, error) {
+	sameDay := 0
+	if receiver.Preferences.DailyLimit > 0 {
+		// 1日は受信者のタイムゾーンで区切る
+		year, month, day := t.In(receiver.Location()).Date()
+		start := time.Date(year, month, day, 0, 0, 0, 0, receiver.Location())
+		received, err := morningCallRepo.ListByReceiverBetween(ctx, receiver.ID, start, start.AddDate(0, 0, 1))
+		if err != nil {
+			return "", err
+		}
+
+		for _, mc := range received {
+			if mc.ID != excludeID && mc.Status != domain.MorningCallStatusDeleted {
+				sameDay++
+			}
+		}
+	}
+
+	if ng := receiver.CanReceiveMorningCall(senderID, t, sameDay); ng.IsNG() {
+		return ng, nil
+	}
+
+	return scheduleConflictNG(ctx, morningCallRepo, receiver, t, excludeID)
+}
+
+// scheduleConflictNG は受信者宛ての他のモーニングコールと時刻が重複・近接していないかを NGReason で返します
+// 送信者を問わず、受信者が設定した最小間隔で判定します
+func scheduleConflictNG(ctx context.Context, morningCallRepo repository.MorningCallRepository, receiver *domain.User, t time.Time, excludeID domain.MorningCallID) (domain.NGReason, error) {
+	gap := receiver.Preferences.MinimumGap
+
+	// 検索範囲は前後 gap とし、同時刻を含めるため最低でも1秒の幅を取る
+	window := max(gap, time.Second)
+	nearby, err := morningCallRepo.ListByReceiverBetween(ctx, receiver.ID, t.Add(-window), t.Add(window))
+	if err != nil {
+		return "", err
+	}
+
+	return domain.ScheduleConflict(t, gap, nearby, excludeID), nil
+}
+
+// checkMorningHours は時刻が受信者の現地時刻でモーニングコールの時間帯に入っているかを確認します
+func checkMorningHours(t time.Time, loc *time.Location) error {
+	if !validation.IsMorningCallTime(t, loc) {
+		return ngReasonError(domain.NGReasonOutsideMorningHours)
+	}
+	return nil
+}
+
 func (rcv *morningCallUsecase) SaveFriendMorningCall(ctx context.Context, userID, friendID domain.UserID, morningCall *domain.MorningCall) error {
-	friend, err := rcv.userRepo.FindByID(ctx, friendID)
+	receiver, err := checkCanAcceptMorningCall(ctx, rcv.userRepo, rcv.relationshipRepo, userID, friendID)
 	if err != nil {
 		return err
 	}
 
-	if ng := friend.CanAcceptMorningCall(userID); ng.IsNG() {
-		return fmt.Errorf("次の理由によりモーニングコールを設定できません。 %s", ng.String())
+	// 送信者・受信者・ステータスはサーバー側で決定する
+	if morningCall.ID == "" {
+		id, err := newMorningCallID()
+		if err != nil {
+			return err
+		}
+		morningCall.ID = id
 	}
+	morningCall.SenderID = userID
+	morningCall.ReceiverID = friendID
+	morningCall.Status = domain.MorningCallStatusScheduled
 
-	if err := rcv.morningCallRepo.Save(ctx, morningCall); err != nil {
+	// 時刻の妥当性チェック（時間帯は受信者のタイムゾーンで判定する）
+	now := rcv.clock.Now()
+	if ng := morningCall.ValidateScheduledTime(now); ng.IsNG() {
+		return ngReasonError(ng)
+	}
+	if err := checkMorningHours(morningCall.Time, receiver.Location()); err != nil {
 		return err
 	}
 
-	return nil
+	return rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 件数の上限や重複は、同時に保存された分も含めて判定するためトランザクション内で確認する
+		if err := checkReceiverPreferences(ctx, rcv.morningCallRepo, receiver, userID, morningCall.Time, morningCall.ID); err != nil {
+			return err
+		}
+
+		if err := rcv.morningCallRepo.Save(ctx, morningCall); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, rcv.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallCreated, morningCall, "", now)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, rcv.outboxRepo, now, morningCallScheduled(morningCall))
+	})
 }
 
 func (rcv *morningCallUsecase) GetFriendMorningCall(ctx context.Context, userID, friendID domain.UserID, morningCallID domain.MorningCallID) (*domain.MorningCall, error) {
@@ -45,37 +171,51 @@ func (rcv *morningCallUsecase) GetFriendMorningCall(ctx context.Context, userID,
 
 	// アクセス権限チェック（送信者または受信者のみアクセス可能）
 	if morningCall.SenderID != userID && morningCall.ReceiverID != userID {
-		return nil, fmt.Errorf("アクセス権限がありません")
+		return nil, ngReasonError(domain.NGReasonNoPermission)
 	}
 
 	// フレンド関係チェック
 	if morningCall.SenderID == userID && morningCall.ReceiverID != friendID {
-		return nil, fmt.Errorf("指定されたフレンドのモーニングコールではありません")
+		return nil, ngReasonError(domain.NGReasonMorningCallNotFound)
 	}
 	if morningCall.ReceiverID == userID && morningCall.SenderID != friendID {
-		return nil, fmt.Errorf("指定されたフレンドのモーニングコールではありません")
+		return nil, ngReasonError(domain.NGReasonMorningCallNotFound)
 	}
 
 	return morningCall, nil
 }
 
-func (rcv *morningCallUsecase) ListMorningCalls(ctx context.Context, userID domain.UserID) ([]*domain.MorningCall, error) {
-	// 送信者として送ったモーニングコール
-	sentCalls, err := rcv.morningCallRepo.ListBySenderID(ctx, userID)
-	if err != nil {
-		return nil, err
+func (rcv *morningCallUsecase) ListMorningCalls(ctx context.Context, userID domain.UserID, query domain.MorningCallQuery, cursor string) ([]*domain.MorningCall, string, error) {
+	query.UserID = userID
+	if err := validateMorningCallQuery(&query); err != nil {
+		return nil, "", err
 	}
 
-	// 受信者として受け取ったモーニングコール
-	receivedCalls, err := rcv.morningCallRepo.ListByReceiverID(ctx, userID)
-	if err != nil {
-		return nil, err
+	if cursor != "" {
+		after, err := decodeMorningCallCursor(cursor, query.Descending)
+		if err != nil {
+			return nil, "", err
+		}
+		query.After = after
 	}
 
-	// 両方のリストを結合
-	allCalls := append(sentCalls, receivedCalls...)
+	// 次のページの有無を判定するため1件多く取得する
+	limit := query.Limit
+	query.Limit = limit + 1
+	morningCalls, err := rcv.morningCallRepo.Search(ctx, query)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(morningCalls) <= limit {
+		return morningCalls, "", nil
+	}
 
-	return allCalls, nil
+	morningCalls = morningCalls[:limit]
+	next, err := encodeMorningCallCursor(domain.CursorOf(morningCalls[limit-1]), query.Descending)
+	if err != nil {
+		return nil, "", err
+	}
+	return morningCalls, next, nil
 }
 
 func (rcv *morningCallUsecase) UpdateMorningCall(ctx context.Context, userID domain.UserID, morningCall *domain.MorningCall) error {
@@ -87,19 +227,54 @@ func (rcv *morningCallUsecase) UpdateMorningCall(ctx context.Context, userID dom
 
 	// 更新権限チェック（送信者のみ更新可能）
 	if ng := existingCall.CanUpdate(userID); ng.IsNG() {
-		return fmt.Errorf("モーニングコールを更新できません: %s", ng.String())
+		return ngReasonError(ng)
+	}
+
+	// 時刻の妥当性チェック（時間帯は受信者のタイムゾーンで判定する）
+	if ng := morningCall.ValidateScheduledTime(rcv.clock.Now()); ng.IsNG() {
+		return ngReasonError(ng)
+	}
+	receiver, err := rcv.userRepo.FindByID(ctx, existingCall.ReceiverID)
+	if err != nil {
+		return err
+	}
+	if err := checkMorningHours(morningCall.Time, receiver.Location()); err != nil {
+		return err
 	}
 
-	// 時刻の妥当性チェック
-	if ng := morningCall.ValidateScheduledTime(); ng.IsNG() {
-		return fmt.Errorf("無効な時刻設定: %s", ng.String())
+	// バージョンの指定がなければ取得時点のバージョンを前提に更新する
+	if morningCall.Version == 0 {
+		morningCall.Version = existingCall.Version
 	}
 
-	// 更新実行
-	return rcv.morningCallRepo.Update(ctx, morningCall)
+	// 送信者・受信者・ステータスは既存の値を引き継ぐ
+	morningCall.SenderID = existingCall.SenderID
+	morningCall.ReceiverID = existingCall.ReceiverID
+	morningCall.Status = existingCall.Status
+	morningCall.SeriesID = existingCall.SeriesID
+
+	return rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 件数の上限や重複は、同時に保存された分も含めて判定するためトランザクション内で確認する
+		if err := checkReceiverPreferences(ctx, rcv.morningCallRepo, receiver, existingCall.SenderID, morningCall.Time, existingCall.ID); err != nil {
+			return err
+		}
+
+		// 繰り返し設定の発生分を別時刻に移した場合、元の時刻は再生成しない
+		if morningCall.SeriesID != "" && !morningCall.Time.Equal(existingCall.Time) {
+			if err := skipSeriesOccurrence(ctx, rcv.seriesRepo, morningCall.SeriesID, existingCall.Time, rcv.clock.Now()); err != nil {
+				return err
+			}
+		}
+
+		// 更新実行
+		if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+			return err
+		}
+		return recordAudit(ctx, rcv.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallUpdated, morningCall, existingCall.Status, rcv.clock.Now()))
+	})
 }
 
-func (rcv *morningCallUsecase) DeleteMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID) error {
+func (rcv *morningCallUsecase) DeleteMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID, version int) error {
 	// モーニングコールを取得
 	morningCall, err := rcv.morningCallRepo.FindByID(ctx, morningCallID)
 	if err != nil {
@@ -108,9 +283,195 @@ func (rcv *morningCallUsecase) DeleteMorningCall(ctx context.Context, userID dom
 
 	// 削除権限チェック（送信者のみ削除可能）
 	if ng := morningCall.CanDelete(userID); ng.IsNG() {
-		return fmt.Errorf("モーニングコールを削除できません: %s", ng.String())
+		return ngReasonError(ng)
+	}
+
+	// クライアントが取得した後に更新されていれば削除しない
+	if version != 0 && version != morningCall.Version {
+		return apperrors.VersionConflictError("morning call", version, morningCall.Version).WithDetails("id", morningCallID)
+	}
+
+	// 削除済みとして残し、復元期間の経過後にパージする
+	now := rcv.clock.Now()
+	before := morningCall.Status
+	morningCall.SoftDelete(now)
+
+	return rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 繰り返し設定の発生分を削除した場合、同じ時刻を再生成しない
+		if morningCall.SeriesID != "" {
+			if err := skipSeriesOccurrence(ctx, rcv.seriesRepo, morningCall.SeriesID, morningCall.Time, now); err != nil {
+				return err
+			}
+		}
+
+		if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+			return err
+		}
+		return recordAudit(ctx, rcv.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallDeleted, morningCall, before, now))
+	})
+}
+
+func (rcv *morningCallUsecase) RestoreMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID) (*domain.MorningCall, error) {
+	// モーニングコールを取得
+	morningCall, err := rcv.morningCallRepo.FindByID(ctx, morningCallID)
+	if err != nil {
+		return nil, err
 	}
 
-	// 削除実行
-	return rcv.morningCallRepo.Delete(ctx, morningCallID)
+	// 復元可能かチェック（送信者または受信者のみ・復元期間内のみ）
+	now := rcv.clock.Now()
+	if ng := morningCall.CanRestore(userID, now); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	// 削除後にフレンド関係が解消・ブロックされていれば復元しない
+	if _, err := checkCanAcceptMorningCall(ctx, rcv.userRepo, rcv.relationshipRepo, morningCall.SenderID, morningCall.ReceiverID); err != nil {
+		return nil, err
+	}
+
+	morningCall.Restore()
+
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 削除時にスキップした繰り返し設定の発生分を元に戻す
+		if morningCall.SeriesID != "" {
+			series, err := rcv.seriesRepo.FindByID(ctx, morningCall.SeriesID)
+			if err != nil {
+				return err
+			}
+			series.Unskip(morningCall.Time, now)
+			if err := rcv.seriesRepo.Update(ctx, series); err != nil {
+				return err
+			}
+		}
+
+		if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+			return err
+		}
+		return recordAudit(ctx, rcv.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallRestored, morningCall, domain.MorningCallStatusDeleted, now))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return morningCall, nil
+}
+
+// PurgeDeleted は削除から retention 以上経過したモーニングコールを完全に削除します
+func (rcv *morningCallUsecase) PurgeDeleted(ctx context.Context, retention time.Duration) error {
+	deleted, err := rcv.morningCallRepo.ListByStatus(ctx, domain.MorningCallStatusDeleted)
+	if err != nil {
+		return err
+	}
+
+	now := rcv.clock.Now()
+	cutoff := now.Add(-retention)
+
+	var errs []error
+	for _, mc := range deleted {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if mc.DeletedAt.After(cutoff) {
+			continue
+		}
+
+		err := rcv.txManager.Do(ctx, func(ctx context.Context) error {
+			if err := rcv.morningCallRepo.Delete(ctx, mc.ID); err != nil {
+				return err
+			}
+			entry := morningCallAudit(domain.SystemActor, domain.AuditActionMorningCallPurged, mc, domain.MorningCallStatusDeleted, now)
+			entry.AfterStatus = ""
+			return recordAudit(ctx, rcv.auditRepo, entry)
+		})
+		if err != nil && !apperrors.IsNotFoundError(err) {
+			errs = append(errs, fmt.Errorf("failed to purge morning call %s: %w", mc.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (rcv *morningCallUsecase) AcknowledgeMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID) (*domain.MorningCall, error) {
+	// モーニングコールを取得
+	morningCall, err := rcv.morningCallRepo.FindByID(ctx, morningCallID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 応答可能かチェック（受信者のみ・応答期限内のみ）
+	now := rcv.clock.Now()
+	if ng := morningCall.CanAcknowledge(userID, now); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	before := morningCall.Status
+	morningCall.Status = domain.MorningCallStatusAcknowledged
+	morningCall.AcknowledgedAt = now
+
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, rcv.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallAcknowledged, morningCall, before, now)); err != nil {
+			return err
+		}
+		return recordEvents(ctx, rcv.outboxRepo, now, domain.MorningCallAcknowledged{
+			MorningCallID:  morningCall.ID,
+			SenderID:       morningCall.SenderID,
+			ReceiverID:     morningCall.ReceiverID,
+			AcknowledgedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return morningCall, nil
+}
+
+func (rcv *morningCallUsecase) SnoozeMorningCall(ctx context.Context, userID domain.UserID, morningCallID domain.MorningCallID, duration time.Duration) (*domain.MorningCall, error) {
+	// スヌーズ時間の妥当性チェック
+	if !validation.ValidateDuration(duration) {
+		return nil, ngReasonError(domain.NGReasonInvalidSnooze)
+	}
+
+	// モーニングコールを取得
+	morningCall, err := rcv.morningCallRepo.FindByID(ctx, morningCallID)
+	if err != nil {
+		return nil, err
+	}
+
+	// スヌーズ可能かチェック（受信者のみ・配信中のみ・回数制限）
+	now := rcv.clock.Now()
+	if ng := morningCall.CanSnooze(userID, now); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	// 指定時間後に再配信する
+	before := morningCall.Status
+	morningCall.Status = domain.MorningCallStatusSnoozed
+	morningCall.SnoozeCount++
+	morningCall.SnoozedUntil = now.Add(duration)
+	morningCall.AckDeadline = time.Time{}
+
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := rcv.morningCallRepo.Update(ctx, morningCall); err != nil {
+			return err
+		}
+		return recordAudit(ctx, rcv.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallSnoozed, morningCall, before, now))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return morningCall, nil
+}
+
+// newMorningCallID は新しいモーニングコールIDを生成するヘルパー関数
+func newMorningCallID() (domain.MorningCallID, error) {
+	newUUID, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate morning call ID: %w", err)
+	}
+	return domain.MorningCallID(newUUID.String()), nil
 }
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+const (
+	// defaultMorningCallPageSize は件数の指定がない場合の1ページの件数です
+	defaultMorningCallPageSize = 50
+	// maxMorningCallPageSize は1ページで取得できる最大件数です
+	maxMorningCallPageSize = 100
+)
+
+// morningCallCursor はページングカーソルの内容です
+// 並び順が変わると位置の意味も変わるため、発行時の並び順も保持します
+type morningCallCursor struct {
+	Time       time.Time            `json:"t"`
+	ID         domain.MorningCallID `json:"id"`
+	Descending bool                 `json:"desc,omitempty"`
+}
+
+// validateMorningCallQuery は検索条件の妥当性をチェックし、件数の既定値を設定します
+func validateMorningCallQuery(query *domain.MorningCallQuery) error {
+	if !query.Direction.IsValid() {
+		return apperrors.ValidationError("direction", domain.NGReasonInvalidParameter.String())
+	}
+	for _, status := range query.Statuses {
+		if !status.IsValid() {
+			return apperrors.ValidationError("status", domain.NGReasonInvalidParameter.String())
+		}
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return apperrors.ValidationError("to", domain.NGReasonInvalidParameter.String())
+	}
+
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultMorningCallPageSize
+	case query.Limit < 0 || query.Limit > maxMorningCallPageSize:
+		return apperrors.ValidationError("limit", domain.NGReasonInvalidParameter.String())
+	}
+	return nil
+}
+
+// encodeMorningCallCursor はページの位置をクライアントに渡す不透明な文字列に変換します
+func encodeMorningCallCursor(cursor domain.MorningCallCursor, descending bool) (string, error) {
+	data, err := json.Marshal(morningCallCursor{Time: cursor.Time, ID: cursor.ID, Descending: descending})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeMorningCallCursor はクライアントから受け取ったカーソルを復元します
+func decodeMorningCallCursor(s string, descending bool) (*domain.MorningCallCursor, error) {
+	invalid := apperrors.ValidationError("cursor", domain.NGReasonInvalidParameter.String())
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	var cursor morningCallCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, invalid
+	}
+	// 発行時と異なる並び順では使えない
+	if cursor.Descending != descending {
+		return nil, invalid
+	}
+
+	return &domain.MorningCallCursor{Time: cursor.Time, ID: cursor.ID}, nil
+}
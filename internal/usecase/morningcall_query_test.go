@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+func TestValidateMorningCallQuery(t *testing.T) {
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     domain.MorningCallQuery
+		wantLimit int
+		wantField string // 空の場合は妥当
+	}{
+		{name: "default limit", query: domain.MorningCallQuery{}, wantLimit: defaultMorningCallPageSize},
+		{name: "explicit limit", query: domain.MorningCallQuery{Limit: 10}, wantLimit: 10},
+		{name: "maximum limit", query: domain.MorningCallQuery{Limit: maxMorningCallPageSize}, wantLimit: maxMorningCallPageSize},
+		{name: "limit over maximum", query: domain.MorningCallQuery{Limit: maxMorningCallPageSize + 1}, wantField: "limit"},
+		{name: "negative limit", query: domain.MorningCallQuery{Limit: -1}, wantField: "limit"},
+		{name: "direction", query: domain.MorningCallQuery{Direction: domain.MorningCallDirectionReceived}, wantLimit: defaultMorningCallPageSize},
+		{name: "unknown direction", query: domain.MorningCallQuery{Direction: "both"}, wantField: "direction"},
+		{name: "status", query: domain.MorningCallQuery{Statuses: []domain.MorningCallStatus{domain.MorningCallStatusDeleted}}, wantLimit: defaultMorningCallPageSize},
+		{name: "unknown status", query: domain.MorningCallQuery{Statuses: []domain.MorningCallStatus{domain.MorningCallStatusScheduled, "sleeping"}}, wantField: "status"},
+		{name: "same from and to", query: domain.MorningCallQuery{From: from, To: from}, wantLimit: defaultMorningCallPageSize},
+		{name: "to before from", query: domain.MorningCallQuery{From: from, To: from.Add(-time.Second)}, wantField: "to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			err := validateMorningCallQuery(&query)
+
+			if tt.wantField != "" {
+				var domainErr *apperrors.DomainError
+				if !errors.As(err, &domainErr) || !apperrors.IsValidationError(err) || domainErr.Details["field"] != tt.wantField {
+					t.Errorf("validateMorningCallQuery error = %v, want a validation error for %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateMorningCallQuery: %v", err)
+			}
+			if query.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", query.Limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestMorningCallCursor(t *testing.T) {
+	position := domain.MorningCallCursor{Time: time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), ID: "mc-1"}
+	encode := func(descending bool) string {
+		s, err := encodeMorningCallCursor(position, descending)
+		if err != nil {
+			t.Fatalf("encodeMorningCallCursor: %v", err)
+		}
+		return s
+	}
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name       string
+		cursor     string
+		descending bool
+		wantErr    bool
+	}{
+		{name: "ascending", cursor: encode(false)},
+		{name: "descending", cursor: encode(true), descending: true},
+		{name: "ascending cursor for a descending query", cursor: encode(false), descending: true, wantErr: true},
+		{name: "descending cursor for an ascending query", cursor: encode(true), wantErr: true},
+		{name: "not base64", cursor: "!!!", wantErr: true},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte(`{"t":"2026-10-19T07:00:00Z","id":"mc-1"}`)), wantErr: true},
+		{name: "not json", cursor: raw("mc-1"), wantErr: true},
+		{name: "missing id", cursor: raw(`{"t":"2026-10-19T07:00:00Z"}`), wantErr: true},
+		{name: "invalid time", cursor: raw(`{"t":"tomorrow","id":"mc-1"}`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeMorningCallCursor(tt.cursor, tt.descending)
+			if tt.wantErr {
+				if !apperrors.IsValidationError(err) {
+					t.Errorf("decodeMorningCallCursor error = %v, want a validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeMorningCallCursor: %v", err)
+			}
+			if !got.Time.Equal(position.Time) || got.ID != position.ID {
+				t.Errorf("decodeMorningCallCursor = %+v, want %+v", *got, position)
+			}
+		})
+	}
+}
+
+// 同時刻のモーニングコールがページの境界をまたいでも、重複も欠落もなく ID の順に返す
+func TestListMorningCalls_PagesThroughEqualTimes(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{})
+	for _, id := range []domain.MorningCallID{"d", "b", "e", "a", "c"} {
+		env.saveMorningCall(t, id, at)
+	}
+	env.saveMorningCall(t, "early", at.Add(-time.Hour))
+	env.saveMorningCall(t, "late", at.Add(time.Hour))
+	morningCalls := env.morningCallUsecase()
+
+	tests := []struct {
+		name       string
+		descending bool
+		want       []domain.MorningCallID
+	}{
+		{name: "ascending", want: []domain.MorningCallID{"early", "a", "b", "c", "d", "e", "late"}},
+		{name: "descending", descending: true, want: []domain.MorningCallID{"late", "e", "d", "c", "b", "a", "early"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var got []domain.MorningCallID
+			cursor := ""
+			for pages := 0; ; pages++ {
+				if pages > len(tt.want) {
+					t.Fatalf("paging did not end, got %v", got)
+				}
+				page, next, err := morningCalls.ListMorningCalls(ctx, "receiver", domain.MorningCallQuery{Descending: tt.descending, Limit: 2}, cursor)
+				if err != nil {
+					t.Fatalf("ListMorningCalls: %v", err)
+				}
+				for _, mc := range page {
+					got = append(got, mc.ID)
+				}
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pages = %v, want %v", got, tt.want)
+			}
+
+			// 逆の並び順のカーソルは受け付けない
+			_, next, err := morningCalls.ListMorningCalls(ctx, "receiver", domain.MorningCallQuery{Descending: tt.descending, Limit: 2}, "")
+			if err != nil {
+				t.Fatalf("ListMorningCalls: %v", err)
+			}
+			if _, _, err := morningCalls.ListMorningCalls(ctx, "receiver", domain.MorningCallQuery{Descending: !tt.descending, Limit: 2}, next); !apperrors.IsValidationError(err) {
+				t.Errorf("ListMorningCalls with a cursor of the other order error = %v, want a validation error", err)
+			}
+		})
+	}
+}
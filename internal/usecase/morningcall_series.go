@@ -0,0 +1,398 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	"morning-call/internal/shared/clock"
+	apperrors "morning-call/internal/shared/errors"
+
+	"github.com/google/uuid"
+)
+
+// maxOccurrenceWindow は発生日時の一覧で一度に取得できる期間です
+const maxOccurrenceWindow = 366 * 24 * time.Hour
+
+type morningCallSeriesUsecase struct {
+	seriesRepo       repository.MorningCallSeriesRepository
+	morningCallRepo  repository.MorningCallRepository
+	userRepo         repository.UserRepository
+	relationshipRepo repository.RelationshipRepository
+	outboxRepo       repository.OutboxRepository
+	txManager        repository.TxManager
+	clock            clock.Clock
+	horizon          time.Duration
+}
+
+// NewMorningCallSeriesUsecase は繰り返しモーニングコールのユースケースを生成します
+// horizon は発生分を個別のモーニングコールとして事前に生成しておく期間です
+func NewMorningCallSeriesUsecase(seriesRepo repository.MorningCallSeriesRepository, morningCallRepo repository.MorningCallRepository, userRepo repository.UserRepository, relationshipRepo repository.RelationshipRepository, outboxRepo repository.OutboxRepository, txManager repository.TxManager, clk clock.Clock, horizon time.Duration) MorningCallSeriesUsecase {
+	return &morningCallSeriesUsecase{
+		seriesRepo:       seriesRepo,
+		morningCallRepo:  morningCallRepo,
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		outboxRepo:       outboxRepo,
+		txManager:        txManager,
+		clock:            clk,
+		horizon:          horizon,
+	}
+}
+
+func (rcv *morningCallSeriesUsecase) CreateSeries(ctx context.Context, userID, friendID domain.UserID, series *domain.MorningCallSeries) error {
+	receiver, err := checkCanAcceptMorningCall(ctx, rcv.userRepo, rcv.relationshipRepo, userID, friendID)
+	if err != nil {
+		return err
+	}
+
+	// 受信者が拒否している送信者からは作成できない
+	// 休止期間や時間帯などの設定は、発生分を生成する際に1件ずつ確認する
+	if !receiver.Preferences.AcceptsCaller(userID) {
+		return ngReasonError(domain.NGReasonCallerNotAllowed)
+	}
+
+	// 発生日時は受信者のタイムゾーンの時刻で展開する
+	series.TimeZone = receiver.Location().String()
+
+	now := rcv.clock.Now()
+	if err := validateSeries(series, now); err != nil {
+		return err
+	}
+
+	// 送信者・受信者・ステータスはサーバー側で決定する
+	id, err := newMorningCallSeriesID()
+	if err != nil {
+		return err
+	}
+	series.ID = id
+	series.SenderID = userID
+	series.ReceiverID = friendID
+	series.Status = domain.MorningCallSeriesStatusActive
+	series.Exceptions = nil
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	return rcv.seriesRepo.Save(ctx, series)
+}
+
+func (rcv *morningCallSeriesUsecase) GetSeries(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID) (*domain.MorningCallSeries, error) {
+	series, err := rcv.seriesRepo.FindByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	// アクセス権限チェック（送信者または受信者のみアクセス可能）
+	if series.SenderID != userID && series.ReceiverID != userID {
+		return nil, ngReasonError(domain.NGReasonNoPermission)
+	}
+
+	return series, nil
+}
+
+func (rcv *morningCallSeriesUsecase) ListSeries(ctx context.Context, userID domain.UserID) ([]*domain.MorningCallSeries, error) {
+	sent, err := rcv.seriesRepo.ListBySenderID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := rcv.seriesRepo.ListByReceiverID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(sent, received...), nil
+}
+
+func (rcv *morningCallSeriesUsecase) ListOccurrences(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, from, to time.Time) ([]time.Time, error) {
+	if to.Before(from) || to.Sub(from) > maxOccurrenceWindow {
+		return nil, apperrors.ValidationError("to", domain.NGReasonInvalidParameter.String())
+	}
+
+	series, err := rcv.GetSeries(ctx, userID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	return series.Occurrences(from, to), nil
+}
+
+func (rcv *morningCallSeriesUsecase) SkipOccurrence(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, occurrence time.Time) error {
+	return rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 同時の変更で除外日時などを上書きしないよう、読み込みから更新までをトランザクション内で行う
+		series, err := rcv.seriesRepo.FindByID(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		// 変更可能かチェック（送信者のみ・有効な発生日時のみ）
+		if ng := series.CanChangeFrom(userID, occurrence); ng.IsNG() {
+			return ngReasonError(ng)
+		}
+
+		series.Skip(occurrence, rcv.clock.Now())
+		if err := rcv.seriesRepo.Update(ctx, series); err != nil {
+			return err
+		}
+
+		// 生成済みのモーニングコールも取り消す
+		return rcv.discardMaterialized(ctx, seriesID, func(t time.Time) bool {
+			return t.Equal(occurrence)
+		})
+	})
+}
+
+func (rcv *morningCallSeriesUsecase) UpdateFollowing(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, occurrence time.Time, changes *domain.MorningCallSeries) (*domain.MorningCallSeries, error) {
+	var following *domain.MorningCallSeries
+	err := rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 同時の変更で除外日時などを上書きしないよう、読み込みから更新までをトランザクション内で行う
+		series, err := rcv.seriesRepo.FindByID(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		// 変更可能かチェック（送信者のみ・有効な発生日時のみ）
+		if ng := series.CanChangeFrom(userID, occurrence); ng.IsNG() {
+			return ngReasonError(ng)
+		}
+
+		// 切り出した設定は受信者の現在のタイムゾーンで展開する
+		receiver, err := rcv.userRepo.FindByID(ctx, series.ReceiverID)
+		if err != nil {
+			return err
+		}
+
+		now := rcv.clock.Now()
+
+		// 指定した発生日時以降を新しい設定として切り出す
+		following = &domain.MorningCallSeries{
+			SenderID:   series.SenderID,
+			ReceiverID: series.ReceiverID,
+			Start:      changes.Start,
+			TimeZone:   receiver.Location().String(),
+			Rule:       changes.Rule,
+			Message:    changes.Message,
+			Status:     domain.MorningCallSeriesStatusActive,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if following.Start.IsZero() {
+			following.Start = occurrence
+		}
+		if following.Rule.Freq == "" {
+			following.Rule = series.RemainingRule(occurrence)
+		}
+		if following.Message == "" {
+			following.Message = series.Message
+		}
+		if err := validateSeries(following, now); err != nil {
+			return err
+		}
+
+		id, err := newMorningCallSeriesID()
+		if err != nil {
+			return err
+		}
+		following.ID = id
+
+		series.EndBefore(occurrence, now)
+		if err := rcv.seriesRepo.Update(ctx, series); err != nil {
+			return err
+		}
+		if err := rcv.seriesRepo.Save(ctx, following); err != nil {
+			return err
+		}
+
+		// 切り出した範囲の生成済みモーニングコールは新しい設定から生成し直す
+		return rcv.discardMaterialized(ctx, seriesID, func(t time.Time) bool {
+			return !t.Before(occurrence)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return following, nil
+}
+
+func (rcv *morningCallSeriesUsecase) CancelFollowing(ctx context.Context, userID domain.UserID, seriesID domain.MorningCallSeriesID, occurrence time.Time) error {
+	return rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 同時の変更で除外日時などを上書きしないよう、読み込みから更新までをトランザクション内で行う
+		series, err := rcv.seriesRepo.FindByID(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		// 発生日時の指定がなければ設定全体を終了する
+		if occurrence.IsZero() {
+			occurrence = series.Start
+		}
+
+		// 変更可能かチェック（送信者のみ・有効な発生日時のみ）
+		if ng := series.CanUpdate(userID); ng.IsNG() {
+			return ngReasonError(ng)
+		}
+		if !occurrence.Equal(series.Start) && !series.IsOccurrence(occurrence) {
+			return ngReasonError(domain.NGReasonNotOccurrence)
+		}
+
+		series.EndBefore(occurrence, rcv.clock.Now())
+		if err := rcv.seriesRepo.Update(ctx, series); err != nil {
+			return err
+		}
+
+		return rcv.discardMaterialized(ctx, seriesID, func(t time.Time) bool {
+			return !t.Before(occurrence)
+		})
+	})
+}
+
+// MaterializeDue は直近の発生分を個別のモーニングコールとして生成し、配信処理の対象にします
+func (rcv *morningCallSeriesUsecase) MaterializeDue(ctx context.Context) error {
+	now := rcv.clock.Now()
+	until := now.Add(rcv.horizon)
+
+	active, err := rcv.seriesRepo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, series := range active {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := rcv.materialize(ctx, series, now, until); err != nil {
+			errs = append(errs, fmt.Errorf("failed to materialize series %s: %w", series.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (rcv *morningCallSeriesUsecase) materialize(ctx context.Context, series *domain.MorningCallSeries, now, until time.Time) error {
+	occurrences := series.Occurrences(now, until)
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	// フレンド関係が解消・ブロックされている間は生成しない
+	receiver, err := checkCanAcceptMorningCall(ctx, rcv.userRepo, rcv.relationshipRepo, series.SenderID, series.ReceiverID)
+	if err != nil {
+		if apperrors.IsAuthorizationError(err) {
+			return nil
+		}
+		return err
+	}
+
+	existing, err := rcv.morningCallRepo.ListBySeriesID(ctx, series.ID)
+	if err != nil {
+		return err
+	}
+	generated := make(map[int64]bool, len(existing))
+	for _, mc := range existing {
+		generated[mc.Time.UnixNano()] = true
+	}
+
+	// 1つの設定の発生分はまとめて生成する
+	return rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		for _, occurrence := range occurrences {
+			if generated[occurrence.UnixNano()] {
+				continue
+			}
+
+			// 受信者の受け付け設定（拒否リスト・休止期間・時間帯・1日の上限）や他のモーニングコールとの間隔に合わない発生分は生成しない。
+			// トランザクション内で判定するため、同時に保存された分や先に生成した発生分も含まれる
+			ng, err := receiverPreferencesNG(ctx, rcv.morningCallRepo, receiver, series.SenderID, occurrence, "")
+			if err != nil {
+				return err
+			}
+			if ng.IsNG() {
+				continue
+			}
+
+			id, err := newMorningCallID()
+			if err != nil {
+				return err
+			}
+			morningCall := &domain.MorningCall{
+				ID:         id,
+				SenderID:   series.SenderID,
+				ReceiverID: series.ReceiverID,
+				Time:       occurrence,
+				Message:    series.Message,
+				Status:     domain.MorningCallStatusScheduled,
+				SeriesID:   series.ID,
+			}
+			if err := rcv.morningCallRepo.Save(ctx, morningCall); err != nil {
+				return err
+			}
+			if err := recordEvents(ctx, rcv.outboxRepo, now, morningCallScheduled(morningCall)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// discardMaterialized は生成済みでこれから配信される（予定済み・スヌーズ中の）モーニングコールのうち、条件に合うものを削除します
+func (rcv *morningCallSeriesUsecase) discardMaterialized(ctx context.Context, seriesID domain.MorningCallSeriesID, match func(time.Time) bool) error {
+	calls, err := rcv.morningCallRepo.ListBySeriesID(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	for _, mc := range calls {
+		// スヌーズ中のものも再び配信されるため取り消す
+		if (mc.Status != domain.MorningCallStatusScheduled && mc.Status != domain.MorningCallStatusSnoozed) || !match(mc.Time) {
+			continue
+		}
+		if err := rcv.morningCallRepo.Delete(ctx, mc.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipSeriesOccurrence は繰り返し設定の発生日時を1件スキップします
+func skipSeriesOccurrence(ctx context.Context, seriesRepo repository.MorningCallSeriesRepository, seriesID domain.MorningCallSeriesID, occurrence, now time.Time) error {
+	series, err := seriesRepo.FindByID(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	if series.IsSkipped(occurrence) {
+		return nil
+	}
+
+	series.Skip(occurrence, now)
+	return seriesRepo.Update(ctx, series)
+}
+
+// validateSeries は繰り返し設定の内容を検証します
+func validateSeries(series *domain.MorningCallSeries, now time.Time) error {
+	if err := series.Rule.Validate(); err != nil {
+		return apperrors.ValidationError("rrule", err.Error())
+	}
+
+	// 初回の日時は未来であること
+	if series.Start.Before(now) {
+		return ngReasonError(domain.NGReasonPastTime)
+	}
+
+	// 時刻は設定のタイムゾーン（受信者の現地時刻）で判定する。
+	// 発生日時は同じ現地時刻に展開されるため、初回の時刻を確認すれば十分
+	return checkMorningHours(series.Start, domain.LoadLocation(series.TimeZone))
+}
+
+// newMorningCallSeriesID は新しい繰り返し設定IDを生成するヘルパー関数
+func newMorningCallSeriesID() (domain.MorningCallSeriesID, error) {
+	newUUID, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate morning call series ID: %w", err)
+	}
+	return domain.MorningCallSeriesID(newUUID.String()), nil
+}
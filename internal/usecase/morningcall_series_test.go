@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// materialized は繰り返し設定から生成されたモーニングコールの日時を返します
+func (e *testEnv) materialized(t *testing.T, seriesID domain.MorningCallSeriesID) []time.Time {
+	t.Helper()
+
+	calls, err := e.morningCallRepo.ListBySeriesID(context.Background(), seriesID)
+	if err != nil {
+		t.Fatalf("ListBySeriesID: %v", err)
+	}
+	times := make([]time.Time, 0, len(calls))
+	for _, mc := range calls {
+		times = append(times, mc.Time)
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	return times
+}
+
+func TestMaterializeDue_SkipsOccurrencesRejectedByReceiverPreferences(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return start.AddDate(0, 0, d) }
+
+	tests := []struct {
+		name     string
+		prefs    domain.ReceiverPreferences
+		existing []time.Time
+		rule     string
+		want     []time.Time
+	}{
+		{
+			name: "no preferences",
+			rule: "FREQ=DAILY;COUNT=5",
+			want: []time.Time{day(0), day(1), day(2), day(3), day(4)},
+		},
+		{
+			name:  "vacation",
+			prefs: domain.ReceiverPreferences{VacationFrom: day(1).Add(-time.Hour), VacationUntil: day(3).Add(-time.Hour)},
+			rule:  "FREQ=DAILY;COUNT=5",
+			want:  []time.Time{day(0), day(3), day(4)},
+		},
+		{
+			name:  "outside wake window",
+			prefs: domain.ReceiverPreferences{WakeWindow: domain.WakeWindow{Start: 8 * 60, End: 10 * 60}},
+			rule:  "FREQ=DAILY;COUNT=5",
+			want:  []time.Time{},
+		},
+		{
+			name:     "daily limit",
+			prefs:    domain.ReceiverPreferences{DailyLimit: 1},
+			existing: []time.Time{day(2).Add(2 * time.Hour)},
+			rule:     "FREQ=DAILY;COUNT=5",
+			want:     []time.Time{day(0), day(1), day(3), day(4)},
+		},
+		{
+			name:     "duplicate schedule",
+			existing: []time.Time{day(1)},
+			rule:     "FREQ=DAILY;COUNT=3",
+			want:     []time.Time{day(0), day(2)},
+		},
+		{
+			name:     "minimum gap",
+			prefs:    domain.ReceiverPreferences{MinimumGap: 30 * time.Minute},
+			existing: []time.Time{day(1).Add(10 * time.Minute), day(2).Add(time.Hour)},
+			rule:     "FREQ=DAILY;COUNT=3",
+			want:     []time.Time{day(0), day(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(now)
+			env.addFriends(t, tt.prefs)
+			seriesUsecase := env.seriesUsecase()
+			for i, at := range tt.existing {
+				env.saveMorningCall(t, domain.MorningCallID("existing-"+string(rune('a'+i))), at)
+			}
+
+			rule, err := domain.ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule: %v", err)
+			}
+			series := &domain.MorningCallSeries{Start: start, Rule: rule, Message: "おはよう"}
+			if err := seriesUsecase.CreateSeries(ctx, "sender", "receiver", series); err != nil {
+				t.Fatalf("CreateSeries: %v", err)
+			}
+
+			if err := seriesUsecase.MaterializeDue(ctx); err != nil {
+				t.Fatalf("MaterializeDue: %v", err)
+			}
+			if got := env.materialized(t, series.ID); !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("materialized = %v, want %v", got, tt.want)
+			}
+
+			// 読み飛ばした発生分は次回以降も生成しない
+			if err := seriesUsecase.MaterializeDue(ctx); err != nil {
+				t.Fatalf("second MaterializeDue: %v", err)
+			}
+			if got := env.materialized(t, series.ID); len(got) != len(tt.want) {
+				t.Errorf("materialized after second run = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 同じ受信者宛ての複数の設定でも、同じ実行で先に生成した分を1日の上限に数える
+func TestMaterializeDue_CountsOccurrencesSavedInTheSameRun(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{DailyLimit: 1})
+	seriesUsecase := env.seriesUsecase()
+
+	var ids []domain.MorningCallSeriesID
+	for _, at := range []time.Time{start, start.Add(time.Hour)} {
+		rule, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=2")
+		if err != nil {
+			t.Fatalf("ParseRecurrenceRule: %v", err)
+		}
+		series := &domain.MorningCallSeries{Start: at, Rule: rule}
+		if err := seriesUsecase.CreateSeries(ctx, "sender", "receiver", series); err != nil {
+			t.Fatalf("CreateSeries: %v", err)
+		}
+		ids = append(ids, series.ID)
+	}
+
+	if err := seriesUsecase.MaterializeDue(ctx); err != nil {
+		t.Fatalf("MaterializeDue: %v", err)
+	}
+
+	total := 0
+	for _, id := range ids {
+		total += len(env.materialized(t, id))
+	}
+	if total != 2 {
+		t.Errorf("materialized %d calls over two days, want 2 with a daily limit of 1", total)
+	}
+}
+
+func TestCreateSeries_RejectsDeniedSender(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{DeniedCallers: []domain.UserID{"sender"}})
+	seriesUsecase := env.seriesUsecase()
+
+	rule, err := domain.ParseRecurrenceRule("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule: %v", err)
+	}
+	series := &domain.MorningCallSeries{Start: time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), Rule: rule}
+	if err := seriesUsecase.CreateSeries(ctx, "sender", "receiver", series); !apperrors.IsAuthorizationError(err) {
+		t.Errorf("CreateSeries error = %v, want an authorization error", err)
+	}
+}
+
+// 作成後に拒否リストへ追加された場合は、以降の発生分を生成しない
+func TestMaterializeDue_StopsAfterSenderIsDenied(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{})
+	seriesUsecase := env.seriesUsecase()
+
+	rule, err := domain.ParseRecurrenceRule("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule: %v", err)
+	}
+	series := &domain.MorningCallSeries{Start: time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC), Rule: rule}
+	if err := seriesUsecase.CreateSeries(ctx, "sender", "receiver", series); err != nil {
+		t.Fatalf("CreateSeries: %v", err)
+	}
+
+	receiver, err := env.userRepo.FindByID(ctx, "receiver")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	receiver.Preferences.DeniedCallers = []domain.UserID{"sender"}
+	if err := env.userRepo.Update(ctx, receiver); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if err := seriesUsecase.MaterializeDue(ctx); err != nil {
+		t.Fatalf("MaterializeDue: %v", err)
+	}
+	if got := env.materialized(t, series.ID); len(got) != 0 {
+		t.Errorf("materialized = %v, want none", got)
+	}
+}
+
+// newDailySeries は sender から receiver への翌朝から毎日の設定を作成し、直近の発生分を生成します
+func newDailySeries(t *testing.T, env *testEnv, seriesUsecase MorningCallSeriesUsecase, start time.Time) *domain.MorningCallSeries {
+	t.Helper()
+	ctx := context.Background()
+
+	rule, err := domain.ParseRecurrenceRule("FREQ=DAILY;COUNT=5")
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule: %v", err)
+	}
+	series := &domain.MorningCallSeries{Start: start, Rule: rule}
+	if err := seriesUsecase.CreateSeries(ctx, "sender", "receiver", series); err != nil {
+		t.Fatalf("CreateSeries: %v", err)
+	}
+	if err := seriesUsecase.MaterializeDue(ctx); err != nil {
+		t.Fatalf("MaterializeDue: %v", err)
+	}
+	return series
+}
+
+// スヌーズ中の発生分も、スキップや「この日以降」の終了で取り消す
+func TestSeriesChanges_DiscardSnoozedOccurrences(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return start.AddDate(0, 0, d) }
+
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, seriesUsecase MorningCallSeriesUsecase, seriesID domain.MorningCallSeriesID) error
+		want   []time.Time
+	}{
+		{
+			name: "skip",
+			change: func(ctx context.Context, seriesUsecase MorningCallSeriesUsecase, seriesID domain.MorningCallSeriesID) error {
+				return seriesUsecase.SkipOccurrence(ctx, "sender", seriesID, day(1))
+			},
+			want: []time.Time{day(0), day(2), day(3), day(4)},
+		},
+		{
+			name: "cancel following",
+			change: func(ctx context.Context, seriesUsecase MorningCallSeriesUsecase, seriesID domain.MorningCallSeriesID) error {
+				return seriesUsecase.CancelFollowing(ctx, "sender", seriesID, day(1))
+			},
+			want: []time.Time{day(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(now)
+			env.addFriends(t, domain.ReceiverPreferences{})
+			seriesUsecase := env.seriesUsecase()
+			series := newDailySeries(t, env, seriesUsecase, start)
+
+			calls, err := env.morningCallRepo.ListBySeriesID(ctx, series.ID)
+			if err != nil {
+				t.Fatalf("ListBySeriesID: %v", err)
+			}
+			for _, mc := range calls {
+				if !mc.Time.Equal(day(1)) {
+					continue
+				}
+				mc.Status = domain.MorningCallStatusSnoozed
+				mc.SnoozedUntil = day(1).Add(10 * time.Minute)
+				if err := env.morningCallRepo.Update(ctx, mc); err != nil {
+					t.Fatalf("Update: %v", err)
+				}
+			}
+
+			if err := tt.change(ctx, seriesUsecase, series.ID); err != nil {
+				t.Fatalf("change: %v", err)
+			}
+			if got := env.materialized(t, series.ID); !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("materialized = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 同時にスキップしても、互いの除外日時を上書きしない
+func TestSkipOccurrence_ConcurrentSkipsKeepEveryException(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{})
+	seriesUsecase := env.seriesUsecase()
+	series := newDailySeries(t, env, seriesUsecase, start)
+	env.seriesRepo.findDelay = 5 * time.Millisecond
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = seriesUsecase.SkipOccurrence(ctx, "sender", series.ID, start.AddDate(0, 0, i))
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("SkipOccurrence(day %d): %v", i, err)
+		}
+	}
+
+	got, err := env.seriesRepo.FindByID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if len(got.Exceptions) != len(errs) {
+		t.Errorf("Exceptions = %v, want %d", got.Exceptions, len(errs))
+	}
+	if remaining := env.materialized(t, series.ID); len(remaining) != 1 {
+		t.Errorf("materialized = %v, want only the last occurrence", remaining)
+	}
+}
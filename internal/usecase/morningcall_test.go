@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// 同時に保存しても、受信者の1日の上限や重複の判定をすり抜けない
+func TestSaveFriendMorningCall_ConcurrentSavesRespectReceiverPreferences(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		prefs domain.ReceiverPreferences
+		time  func(i int) time.Time
+	}{
+		{"daily limit", domain.ReceiverPreferences{DailyLimit: 1}, func(i int) time.Time { return at.Add(time.Duration(i) * 10 * time.Minute) }},
+		{"duplicate schedule", domain.ReceiverPreferences{}, func(int) time.Time { return at }},
+		{"minimum gap", domain.ReceiverPreferences{MinimumGap: time.Hour}, func(i int) time.Time { return at.Add(time.Duration(i) * time.Minute) }},
+	}
+
+	const callers = 8
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(now)
+			env.addFriends(t, tt.prefs)
+			env.morningCallRepo.listDelay = 5 * time.Millisecond
+
+			morningCalls := env.morningCallUsecase()
+
+			var wg sync.WaitGroup
+			errs := make([]error, callers)
+			for i := range callers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = morningCalls.SaveFriendMorningCall(ctx, "sender", "receiver", &domain.MorningCall{
+						ID:   domain.MorningCallID(fmt.Sprintf("mc-%d", i)),
+						Time: tt.time(i),
+					})
+				}()
+			}
+			wg.Wait()
+
+			saved := 0
+			for _, err := range errs {
+				switch {
+				case err == nil:
+					saved++
+				case !apperrors.IsConflictError(err):
+					t.Errorf("SaveFriendMorningCall error = %v, want a conflict", err)
+				}
+			}
+			if saved != 1 {
+				t.Errorf("saved %d morning calls, want 1", saved)
+			}
+		})
+	}
+}
+
+// 更新は自分自身を除いて重複を判定する
+func TestUpdateMorningCall_ChecksScheduleConflict(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{})
+	morningCalls := env.morningCallUsecase()
+
+	for id, t0 := range map[domain.MorningCallID]time.Time{"first": at, "second": at.Add(time.Hour)} {
+		if err := morningCalls.SaveFriendMorningCall(ctx, "sender", "receiver", &domain.MorningCall{ID: id, Time: t0}); err != nil {
+			t.Fatalf("SaveFriendMorningCall(%s): %v", id, err)
+		}
+	}
+
+	if err := morningCalls.UpdateMorningCall(ctx, "sender", &domain.MorningCall{ID: "second", Time: at}); !apperrors.IsConflictError(err) {
+		t.Errorf("UpdateMorningCall to a taken time error = %v, want a conflict", err)
+	}
+	if err := morningCalls.UpdateMorningCall(ctx, "sender", &domain.MorningCall{ID: "second", Time: at.Add(time.Hour), Message: "おはよう"}); err != nil {
+		t.Errorf("UpdateMorningCall keeping its own time: %v", err)
+	}
+}
+
+// クライアントが取得した後に更新されたモーニングコールは上書きも削除もしない
+func TestMorningCall_ChecksVersion(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{})
+	morningCalls := env.morningCallUsecase()
+
+	if err := morningCalls.SaveFriendMorningCall(ctx, "sender", "receiver", &domain.MorningCall{ID: "mc", Time: at}); err != nil {
+		t.Fatalf("SaveFriendMorningCall: %v", err)
+	}
+	updated := &domain.MorningCall{ID: "mc", Time: at, Message: "first", Version: 1}
+	if err := morningCalls.UpdateMorningCall(ctx, "sender", updated); err != nil {
+		t.Fatalf("UpdateMorningCall with the current version: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("Version = %d, want 2 after the update", updated.Version)
+	}
+
+	stale := &domain.MorningCall{ID: "mc", Time: at, Message: "stale", Version: 1}
+	if err := morningCalls.UpdateMorningCall(ctx, "sender", stale); !apperrors.IsConflictError(err) {
+		t.Errorf("UpdateMorningCall with a stale version error = %v, want a conflict", err)
+	}
+	if err := morningCalls.DeleteMorningCall(ctx, "sender", "mc", 1); !apperrors.IsConflictError(err) {
+		t.Errorf("DeleteMorningCall with a stale version error = %v, want a conflict", err)
+	}
+	if mc := env.findMorningCall(t, "mc"); mc.Message != "first" || mc.Status != domain.MorningCallStatusScheduled || mc.Version != 2 {
+		t.Errorf("stored = %q %s v%d, want the first update left in place", mc.Message, mc.Status, mc.Version)
+	}
+
+	if err := morningCalls.DeleteMorningCall(ctx, "sender", "mc", 2); err != nil {
+		t.Errorf("DeleteMorningCall with the current version: %v", err)
+	}
+}
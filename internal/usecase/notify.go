@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+)
+
+// Notification は配信チャネルに渡すモーニングコールの内容です
+type Notification struct {
+	MorningCallID domain.MorningCallID
+	Sender        *domain.User
+	Receiver      *domain.User
+	Message       string
+	ScheduledAt   time.Time
+}
+
+// NotificationResult は1チャネルへの配信結果です
+type NotificationResult struct {
+	Channel     domain.NotificationChannel
+	DeliveredAt time.Time
+	// Detail はチャネル固有の補足情報 (HTTPステータスなど) です
+	Detail string
+}
+
+// Notifier は1つの配信チャネルを表します
+type Notifier interface {
+	Channel() domain.NotificationChannel
+	Notify(ctx context.Context, notification *Notification) (*NotificationResult, error)
+}
+
+// notificationDeliverer は受信者が選択したチャネルへ配信する Deliverer です
+type notificationDeliverer struct {
+	userRepo  repository.UserRepository
+	logger    *slog.Logger
+	notifiers map[domain.NotificationChannel]Notifier
+}
+
+// NewNotificationDeliverer は登録された Notifier を使って配信する Deliverer を生成します
+// チャネルごとの配信結果は logger に記録します
+func NewNotificationDeliverer(userRepo repository.UserRepository, logger *slog.Logger, notifiers ...Notifier) Deliverer {
+	byChannel := make(map[domain.NotificationChannel]Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byChannel[n.Channel()] = n
+	}
+	return &notificationDeliverer{
+		userRepo:  userRepo,
+		logger:    logger,
+		notifiers: byChannel,
+	}
+}
+
+// Deliver は受信者の全チャネルへ配信し、いずれか1つでも成功すれば成功とみなします
+// 一部のチャネルが失敗しても配信は成功となるため、失敗はチャネルごとにログへ記録します
+func (d *notificationDeliverer) Deliver(ctx context.Context, morningCall *domain.MorningCall) error {
+	sender, err := d.userRepo.FindByID(ctx, morningCall.SenderID)
+	if err != nil {
+		return err
+	}
+
+	receiver, err := d.userRepo.FindByID(ctx, morningCall.ReceiverID)
+	if err != nil {
+		return err
+	}
+
+	notification := &Notification{
+		MorningCallID: morningCall.ID,
+		Sender:        sender,
+		Receiver:      receiver,
+		Message:       morningCall.Message,
+		// 受信者の現地時刻で通知する
+		ScheduledAt: morningCall.Time.In(receiver.Location()),
+	}
+
+	channels := receiver.Notification.Channels
+	if len(channels) == 0 {
+		channels = domain.DefaultNotificationSettings().Channels
+	}
+
+	var errs []error
+	delivered := false
+	for _, channel := range channels {
+		result, err := d.notify(ctx, channel, notification)
+		if err != nil {
+			err = fmt.Errorf("notify via %s: %w", channel, err)
+			d.logger.WarnContext(ctx, "notification failed",
+				slog.String("morning_call_id", string(morningCall.ID)),
+				slog.String("channel", string(channel)),
+				slog.Any("error", err),
+			)
+			errs = append(errs, err)
+			continue
+		}
+
+		d.logger.InfoContext(ctx, "notification delivered",
+			slog.String("morning_call_id", string(morningCall.ID)),
+			slog.String("channel", string(result.Channel)),
+			slog.Time("delivered_at", result.DeliveredAt),
+			slog.String("detail", result.Detail),
+		)
+		delivered = true
+	}
+
+	if delivered {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// notify は1つのチャネルへ配信します
+func (d *notificationDeliverer) notify(ctx context.Context, channel domain.NotificationChannel, notification *Notification) (*NotificationResult, error) {
+	notifier, ok := d.notifiers[channel]
+	if !ok {
+		return nil, fmt.Errorf("notification channel %s is not configured", channel)
+	}
+	return notifier.Notify(ctx, notification)
+}
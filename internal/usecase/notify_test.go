@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+)
+
+// fakeNotifier は err が nil なら成功を返す Notifier です
+type fakeNotifier struct {
+	channel domain.NotificationChannel
+	err     error
+}
+
+func (n *fakeNotifier) Channel() domain.NotificationChannel {
+	return n.channel
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notification *Notification) (*NotificationResult, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+	return &NotificationResult{Channel: n.channel, DeliveredAt: time.Now(), Detail: "ok"}, nil
+}
+
+// newTestDeliverer は receiver が channels で通知を受け取る設定の配信処理と、そのログを返します
+func newTestDeliverer(t *testing.T, channels []domain.NotificationChannel, notifiers ...Notifier) (Deliverer, *bytes.Buffer) {
+	t.Helper()
+
+	env := newTestEnv(time.Now())
+	env.createUser(t, &domain.User{ID: "sender"})
+	env.createUser(t, &domain.User{ID: "receiver", Notification: domain.NotificationSettings{Channels: channels}})
+
+	var logs bytes.Buffer
+	return env.deliverer(&logs, notifiers...), &logs
+}
+
+func TestNotificationDeliverer_LogsFailedChannelWhenAnotherSucceeds(t *testing.T) {
+	deliverer, logs := newTestDeliverer(t,
+		[]domain.NotificationChannel{domain.NotificationChannelWebhook, domain.NotificationChannelLog},
+		&fakeNotifier{channel: domain.NotificationChannelWebhook, err: errors.New("connection refused")},
+		&fakeNotifier{channel: domain.NotificationChannelLog},
+	)
+
+	err := deliverer.Deliver(context.Background(), &domain.MorningCall{ID: "mc-1", SenderID: "sender", ReceiverID: "receiver"})
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	out := logs.String()
+	if !strings.Contains(out, "notification failed") || !strings.Contains(out, "channel=webhook") || !strings.Contains(out, "connection refused") {
+		t.Errorf("log does not report the webhook failure:\n%s", out)
+	}
+	if !strings.Contains(out, "notification delivered") || !strings.Contains(out, "channel=log") {
+		t.Errorf("log does not report the log delivery:\n%s", out)
+	}
+}
+
+func TestNotificationDeliverer_FailsWhenEveryChannelFails(t *testing.T) {
+	deliverer, _ := newTestDeliverer(t,
+		[]domain.NotificationChannel{domain.NotificationChannelWebhook, domain.NotificationChannelEmail},
+		&fakeNotifier{channel: domain.NotificationChannelWebhook, err: errors.New("connection refused")},
+	)
+
+	err := deliverer.Deliver(context.Background(), &domain.MorningCall{ID: "mc-1", SenderID: "sender", ReceiverID: "receiver"})
+	if err == nil {
+		t.Fatal("Deliver error = nil, want the channel failures")
+	}
+	for _, want := range []string{"notify via webhook", "notify via email"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Deliver error %q does not contain %q", err, want)
+		}
+	}
+}
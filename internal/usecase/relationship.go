@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+	"morning-call/internal/shared/clock"
+)
+
+type relationshipUsecase struct {
+	relationshipRepo repository.RelationshipRepository
+	userRepo         repository.UserRepository
+	outboxRepo       repository.OutboxRepository
+	txManager        repository.TxManager
+	clock            clock.Clock
+}
+
+// newRelationshipUsecase は UserUsecase の内部で使う RelationshipUsecase を生成します
+// フレンド関係の終了に伴うモーニングコールの取り消しや監査ログは userUsecase が行うため、
+// ハンドラーからは UserUsecase を経由して利用します
+func newRelationshipUsecase(relationshipRepo repository.RelationshipRepository, userRepo repository.UserRepository, outboxRepo repository.OutboxRepository, txManager repository.TxManager, clk clock.Clock) RelationshipUsecase {
+	return &relationshipUsecase{
+		relationshipRepo: relationshipRepo,
+		userRepo:         userRepo,
+		outboxRepo:       outboxRepo,
+		txManager:        txManager,
+		clock:            clk,
+	}
+}
+
+func (rcv *relationshipUsecase) SendRequest(ctx context.Context, requesterID, receiverID domain.UserID) (*domain.Relationship, error) {
+	requester, err := rcv.userRepo.FindByID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 申請先ユーザーの存在確認
+	if _, err := rcv.userRepo.FindByID(ctx, receiverID); err != nil {
+		return nil, err
+	}
+
+	existing, err := rcv.relationshipRepo.FindAllByUsers(ctx, requesterID, receiverID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 申請可能かチェック
+	if ng := requester.CanAddFriend(receiverID, existing); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	relationship := domain.NewRelationship(requesterID, receiverID, rcv.clock.Now())
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		// 拒否済みの申請は破棄して再申請を受け付ける
+		for _, rel := range existing {
+			if err := rcv.relationshipRepo.Delete(ctx, rel); err != nil {
+				return err
+			}
+		}
+		if err := rcv.relationshipRepo.Create(ctx, relationship); err != nil {
+			return err
+		}
+		return recordEvents(ctx, rcv.outboxRepo, relationship.CreatedAt, domain.FriendRequestSent{
+			RelationshipID: relationship.ID,
+			RequesterID:    requesterID,
+			ReceiverID:     receiverID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return relationship, nil
+}
+
+func (rcv *relationshipUsecase) Approve(ctx context.Context, userID domain.UserID, relationshipID domain.RelationshipID) (*domain.Relationship, error) {
+	relationship, err := rcv.react(ctx, userID, relationshipID, (*domain.Relationship).Approve, func(relationship *domain.Relationship) domain.Event {
+		return domain.FriendRequestApproved{
+			RelationshipID: relationship.ID,
+			RequesterID:    relationship.RequesterID,
+			ReceiverID:     relationship.ReceiverID,
+		}
+	})
+	return relationship, err
+}
+
+func (rcv *relationshipUsecase) Reject(ctx context.Context, userID domain.UserID, relationshipID domain.RelationshipID) (*domain.Relationship, error) {
+	return rcv.react(ctx, userID, relationshipID, (*domain.Relationship).Reject, nil)
+}
+
+// react は受信者による承認・拒否の共通処理です
+// event が nil でなければ、その結果のイベントを更新と同じトランザクションで保存します
+func (rcv *relationshipUsecase) react(ctx context.Context, userID domain.UserID, relationshipID domain.RelationshipID, transition func(*domain.Relationship, time.Time) error, event func(*domain.Relationship) domain.Event) (*domain.Relationship, error) {
+	relationship, err := rcv.relationshipRepo.FindByID(ctx, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 受信者のみが応答可能
+	if relationship.ReceiverID != userID {
+		return nil, relationshipError(domain.ErrNotReceiver)
+	}
+
+	if err := transition(relationship, rcv.clock.Now()); err != nil {
+		return nil, relationshipError(err)
+	}
+
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := rcv.relationshipRepo.Update(ctx, relationship); err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+		return recordEvents(ctx, rcv.outboxRepo, relationship.UpdatedAt, event(relationship))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return relationship, nil
+}
+
+func (rcv *relationshipUsecase) Block(ctx context.Context, userID, targetUserID domain.UserID) (*domain.Relationship, error) {
+	user, err := rcv.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rcv.userRepo.FindByID(ctx, targetUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := rcv.relationshipRepo.FindAllByUsers(ctx, userID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ブロック可能かチェック
+	if ng := user.CanBlockUser(targetUserID, existing); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	now := rcv.clock.Now()
+	relationship := domain.NewRelationship(userID, targetUserID, now)
+	relationship.Block(now)
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		for _, rel := range existing {
+			// 相手からのブロックはそのまま残す
+			if rel.IsBlocked() {
+				continue
+			}
+
+			// ブロックは常にブロックした側を要求者とするため、既存の関係は置き換える
+			if err := rcv.relationshipRepo.Delete(ctx, rel); err != nil {
+				return err
+			}
+		}
+		return rcv.relationshipRepo.Create(ctx, relationship)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return relationship, nil
+}
+
+// CancelRequest は userID から targetUserID への承認待ちの申請を取り消します
+func (rcv *relationshipUsecase) CancelRequest(ctx context.Context, userID, targetUserID domain.UserID) (*domain.Relationship, error) {
+	return rcv.end(ctx, userID, targetUserID, (*domain.User).CanCancelFriendRequest, func(rel *domain.Relationship) bool {
+		return rel.IsPending() && rel.RequesterID == userID
+	}, (*domain.Relationship).Cancel)
+}
+
+// Unfriend は userID と friendID のフレンド関係を解消します
+// 解消はどちらのユーザーからでも行えます
+func (rcv *relationshipUsecase) Unfriend(ctx context.Context, userID, friendID domain.UserID) (*domain.Relationship, error) {
+	return rcv.end(ctx, userID, friendID, (*domain.User).CanUnfriend, (*domain.Relationship).IsFriend, (*domain.Relationship).Unfriend)
+}
+
+// end は2人の間で match に合う関係を transition で終了させる共通処理です
+// 関係は1件のため、更新すると双方から見た状態が同時に変わります
+func (rcv *relationshipUsecase) end(ctx context.Context, userID, otherUserID domain.UserID, check func(*domain.User, domain.UserID, []*domain.Relationship) domain.NGReason, match func(*domain.Relationship) bool, transition func(*domain.Relationship, time.Time) error) (*domain.Relationship, error) {
+	user, err := rcv.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rcv.userRepo.FindByID(ctx, otherUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := rcv.relationshipRepo.FindAllByUsers(ctx, userID, otherUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ng := check(user, otherUserID, existing); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	var relationship *domain.Relationship
+	for _, rel := range existing {
+		if match(rel) {
+			relationship = rel
+			break
+		}
+	}
+
+	if err := transition(relationship, rcv.clock.Now()); err != nil {
+		return nil, relationshipError(err)
+	}
+
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		return rcv.relationshipRepo.Update(ctx, relationship)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return relationship, nil
+}
+
+// Unblock は userID によるブロックを解除し、削除したブロックを返します
+// 解除後は双方とも関係のない状態に戻るため、どちらからでも改めてフレンド申請できます
+// 相手からのブロックは解除されません
+func (rcv *relationshipUsecase) Unblock(ctx context.Context, userID, targetUserID domain.UserID) (*domain.Relationship, error) {
+	user, err := rcv.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rcv.userRepo.FindByID(ctx, targetUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := rcv.relationshipRepo.FindAllByUsers(ctx, userID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解除可能かチェック
+	if ng := user.CanUnblockUser(targetUserID, existing); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	block := domain.BlockBy(userID, targetUserID, existing)
+	err = rcv.txManager.Do(ctx, func(ctx context.Context) error {
+		return rcv.relationshipRepo.Delete(ctx, block)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// ListBlocked は userID がブロックしている関係を返します
+func (rcv *relationshipUsecase) ListBlocked(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error) {
+	requested, err := rcv.relationshipRepo.FindByRequester(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ブロックは常にブロックした側が要求者になる
+	var blocked []*domain.Relationship
+	for _, rel := range requested {
+		if rel.IsBlocked() {
+			blocked = append(blocked, rel)
+		}
+	}
+
+	return blocked, nil
+}
+
+func (rcv *relationshipUsecase) ListFriends(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error) {
+	return rcv.relationshipRepo.FindByUserWithStatus(ctx, userID, domain.RelationshipStatusApproved)
+}
+
+func (rcv *relationshipUsecase) ListPendingRequests(ctx context.Context, userID domain.UserID) ([]*domain.Relationship, error) {
+	received, err := rcv.relationshipRepo.FindByReceiver(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 自分宛ての承認待ち申請のみを返す
+	var pending []*domain.Relationship
+	for _, rel := range received {
+		if rel.IsPending() {
+			pending = append(pending, rel)
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"morning-call/internal/domain"
+	"morning-call/internal/repository"
+)
+
+// MigrateRelatedUsers は User.RelatedUsers に保持された旧フレンド関係を Relationship に変換します
+// 変換後は RelatedUsers を空にするため、何度実行しても結果は変わりません
+// 戻り値は新たに作成した Relationship の件数です
+//
+// ブロックは方向ごとに変換し、互いにブロックしている場合は両方のブロックを残します
+// 承認待ちの申請は、片方のユーザーだけが pending を保持していればその側を申請者とします。
+// 旧実装は双方に pending を記録していたため、双方が保持している申請は方向を判別できません。
+// 受信者を取り違えて申請者が自分で承認できてしまわないよう、これらは変換せずに破棄し、改めて申請してもらいます
+// ブロック以外の終了済みの関係は方向を問わないため、ユーザーIDの小さい側を申請者とみなします
+//
+// 変換は1つのトランザクションで行い、途中で失敗した場合は何も変換しません
+func MigrateRelatedUsers(ctx context.Context, userRepo repository.UserRepository, relationshipRepo repository.RelationshipRepository, txManager repository.TxManager) (int, error) {
+	created := 0
+	err := txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = migrateRelatedUsers(ctx, userRepo, relationshipRepo)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+func migrateRelatedUsers(ctx context.Context, userRepo repository.UserRepository, relationshipRepo repository.RelationshipRepository) (int, error) {
+	users, err := userRepo.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	usersByID := make(map[domain.UserID]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	created := 0
+	now := time.Now()
+
+	// ブロックは方向が明確なため先に変換し、他の関係より優先させる
+	for _, user := range users {
+		for _, ru := range user.RelatedUsers {
+			if ru.Status != domain.RelatedUserStatusBlocked {
+				continue
+			}
+
+			// 相手からのブロックがあっても、この方向のブロックは別に作成する
+			existing, err := relationshipRepo.FindAllByUsers(ctx, user.ID, ru.ID)
+			if err != nil {
+				return created, err
+			}
+			if domain.BlockBy(user.ID, ru.ID, existing) != nil {
+				continue
+			}
+
+			if err := relationshipRepo.Create(ctx, migratedRelationship(user.ID, ru.ID, domain.RelationshipStatusBlocked, now)); err != nil {
+				return created, err
+			}
+			created++
+		}
+	}
+
+	for _, user := range users {
+		for _, ru := range user.RelatedUsers {
+			status, ok := migratedRelationshipStatus(ru.Status)
+			if !ok {
+				continue
+			}
+
+			// 双方に同じ関係が記録されているため、既に変換済みの組は読み飛ばす
+			existing, err := relationshipRepo.FindAllByUsers(ctx, user.ID, ru.ID)
+			if err != nil {
+				return created, err
+			}
+			if len(existing) > 0 {
+				continue
+			}
+
+			requesterID, receiverID := user.ID, ru.ID
+			if status == domain.RelationshipStatusPending {
+				// 双方が pending を保持している申請は方向を判別できないため破棄する
+				if holdsPending(usersByID[ru.ID], user.ID) {
+					continue
+				}
+			} else if receiverID < requesterID {
+				requesterID, receiverID = receiverID, requesterID
+			}
+
+			if err := relationshipRepo.Create(ctx, migratedRelationship(requesterID, receiverID, status, now)); err != nil {
+				return created, err
+			}
+			created++
+		}
+	}
+
+	// 変換が完了したユーザーの旧データを削除する
+	for _, user := range users {
+		if len(user.RelatedUsers) == 0 {
+			continue
+		}
+		if err := userRepo.UpdateRelatedUsers(ctx, user.ID, []domain.RelatedUser{}); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+// holdsPending は user が targetID との承認待ちの関係を保持しているかを返します
+func holdsPending(user *domain.User, targetID domain.UserID) bool {
+	if user == nil {
+		return false
+	}
+	for _, ru := range user.RelatedUsers {
+		if ru.ID == targetID && ru.Status.IsPending() {
+			return true
+		}
+	}
+	return false
+}
+
+// migratedRelationshipStatus はブロック以外の RelatedUserStatus を RelationshipStatus に変換します
+func migratedRelationshipStatus(status domain.RelatedUserStatus) (domain.RelationshipStatus, bool) {
+	switch status {
+	case domain.RelatedUserStatusApproved:
+		return domain.RelationshipStatusApproved, true
+	case domain.RelatedUserStatusPending:
+		return domain.RelationshipStatusPending, true
+	case domain.RelatedUserStatusRejected:
+		return domain.RelationshipStatusRejected, true
+	case domain.RelatedUserStatusCancelled:
+		return domain.RelationshipStatusCancelled, true
+	case domain.RelatedUserStatusUnfriended:
+		return domain.RelationshipStatusUnfriended, true
+	case domain.RelatedUserStatusExpired:
+		return domain.RelationshipStatusExpired, true
+	default:
+		return "", false
+	}
+}
+
+func migratedRelationship(requesterID, receiverID domain.UserID, status domain.RelationshipStatus, now time.Time) *domain.Relationship {
+	relationship := domain.NewRelationship(requesterID, receiverID, now)
+	relationship.Status = status
+	return relationship
+}
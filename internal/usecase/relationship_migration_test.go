@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+)
+
+func TestMigrateRelatedUsers(t *testing.T) {
+	type relation struct {
+		requesterID, receiverID domain.UserID
+		status                  domain.RelationshipStatus
+	}
+
+	tests := []struct {
+		name    string
+		related map[domain.UserID][]domain.RelatedUser
+		want    []relation
+	}{
+		{
+			name: "approved on both sides",
+			related: map[domain.UserID][]domain.RelatedUser{
+				"bob":   {{ID: "alice", Status: domain.RelatedUserStatusApproved}},
+				"alice": {{ID: "bob", Status: domain.RelatedUserStatusApproved}},
+			},
+			want: []relation{{"alice", "bob", domain.RelationshipStatusApproved}},
+		},
+		{
+			// 旧実装は申請者と受信者の双方に pending を記録していた
+			name: "pending on both sides is dropped",
+			related: map[domain.UserID][]domain.RelatedUser{
+				"bob":   {{ID: "alice", Status: domain.RelatedUserStatusPending}},
+				"alice": {{ID: "bob", Status: domain.RelatedUserStatusPending}},
+			},
+			want: nil,
+		},
+		{
+			name: "pending on one side",
+			related: map[domain.UserID][]domain.RelatedUser{
+				"bob": {{ID: "alice", Status: domain.RelatedUserStatusPending}},
+			},
+			want: []relation{{"bob", "alice", domain.RelationshipStatusPending}},
+		},
+		{
+			name: "one way block",
+			related: map[domain.UserID][]domain.RelatedUser{
+				"bob": {{ID: "alice", Status: domain.RelatedUserStatusBlocked}},
+			},
+			want: []relation{{"bob", "alice", domain.RelationshipStatusBlocked}},
+		},
+		{
+			name: "mutual block keeps both",
+			related: map[domain.UserID][]domain.RelatedUser{
+				"alice": {{ID: "bob", Status: domain.RelatedUserStatusBlocked}},
+				"bob":   {{ID: "alice", Status: domain.RelatedUserStatusBlocked}},
+			},
+			want: []relation{
+				{"alice", "bob", domain.RelationshipStatusBlocked},
+				{"bob", "alice", domain.RelationshipStatusBlocked},
+			},
+		},
+		{
+			name: "block wins over a friendship on the other side",
+			related: map[domain.UserID][]domain.RelatedUser{
+				"alice": {{ID: "bob", Status: domain.RelatedUserStatusApproved}},
+				"bob":   {{ID: "alice", Status: domain.RelatedUserStatusBlocked}},
+			},
+			want: []relation{{"bob", "alice", domain.RelationshipStatusBlocked}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(time.Now())
+			for _, id := range []domain.UserID{"alice", "bob"} {
+				env.createUser(t, &domain.User{ID: id, RelatedUsers: tt.related[id]})
+			}
+
+			created, err := MigrateRelatedUsers(ctx, env.userRepo, env.relationshipRepo, env.txManager)
+			if err != nil {
+				t.Fatalf("MigrateRelatedUsers: %v", err)
+			}
+			if created != len(tt.want) {
+				t.Errorf("created = %d, want %d", created, len(tt.want))
+			}
+
+			got, err := env.relationshipRepo.FindAllByUsers(ctx, "alice", "bob")
+			if err != nil {
+				t.Fatalf("FindAllByUsers: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("relationships = %d, want %d", len(got), len(tt.want))
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, rel := range got {
+					if rel.RequesterID == want.requesterID && rel.ReceiverID == want.receiverID && rel.Status == want.status {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("missing %s -> %s (%s)", want.requesterID, want.receiverID, want.status)
+				}
+			}
+
+			// 旧データは削除され、再実行しても何も作成しない
+			for _, id := range []domain.UserID{"alice", "bob"} {
+				user, err := env.userRepo.FindByID(ctx, id)
+				if err != nil {
+					t.Fatalf("FindByID(%s): %v", id, err)
+				}
+				if len(user.RelatedUsers) != 0 {
+					t.Errorf("%s RelatedUsers = %v, want none", id, user.RelatedUsers)
+				}
+			}
+			if created, err := MigrateRelatedUsers(ctx, env.userRepo, env.relationshipRepo, env.txManager); err != nil || created != 0 {
+				t.Errorf("second MigrateRelatedUsers = %d, %v, want 0, nil", created, err)
+			}
+		})
+	}
+}
@@ -2,29 +2,67 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"morning-call/internal/domain"
 	"morning-call/internal/repository"
+	"morning-call/internal/shared/auth"
+	"morning-call/internal/shared/clock"
+	apperrors "morning-call/internal/shared/errors"
+	"morning-call/internal/shared/validation"
 
 	"github.com/google/uuid"
 )
 
 type userUsecase struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	relationshipRepo repository.RelationshipRepository
+	morningCallRepo  repository.MorningCallRepository
+	seriesRepo       repository.MorningCallSeriesRepository
+	auditRepo        repository.AuditRepository
+	txManager        repository.TxManager
+	clock            clock.Clock
+	relationships    RelationshipUsecase
 }
 
-func NewUserUsecase(userRepo repository.UserRepository) UserUsecase {
+func NewUserUsecase(userRepo repository.UserRepository, relationshipRepo repository.RelationshipRepository, morningCallRepo repository.MorningCallRepository, seriesRepo repository.MorningCallSeriesRepository, outboxRepo repository.OutboxRepository, auditRepo repository.AuditRepository, txManager repository.TxManager, clk clock.Clock) UserUsecase {
 	return &userUsecase{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		morningCallRepo:  morningCallRepo,
+		seriesRepo:       seriesRepo,
+		auditRepo:        auditRepo,
+		txManager:        txManager,
+		clock:            clk,
+		relationships:    newRelationshipUsecase(relationshipRepo, userRepo, outboxRepo, txManager, clk),
 	}
 }
 
-func (u *userUsecase) Register(ctx context.Context, username, email string) (*domain.User, error) {
+func (u *userUsecase) Register(ctx context.Context, username, email, password, timeZone string) (*domain.User, error) {
+	// 入力値の妥当性チェック
+	if !validation.ValidateUsername(username) {
+		return nil, apperrors.ValidationError("username", domain.NGReasonInvalidUsername.String())
+	}
+	if !validation.ValidateEmail(email) {
+		return nil, apperrors.ValidationError("email", domain.NGReasonInvalidEmail.String())
+	}
+	if !validation.ValidatePassword(password) {
+		return nil, apperrors.ValidationError("password", domain.NGReasonInvalidPassword.String())
+	}
+	if timeZone == "" {
+		timeZone = domain.DefaultTimeZone
+	}
+	if !validation.ValidateTimeZone(timeZone) {
+		return nil, apperrors.ValidationError("time_zone", domain.NGReasonInvalidTimeZone.String())
+	}
+
 	// メールアドレスの重複チェック
 	existingUser, _ := u.userRepo.FindByEmail(ctx, email)
 	if existingUser != nil {
-		return nil, fmt.Errorf("email already registered")
+		return nil, apperrors.ConflictError("email")
 	}
 
 	// ユーザー作成
@@ -32,8 +70,20 @@ func (u *userUsecase) Register(ctx context.Context, username, email string) (*do
 	if err != nil {
 		return nil, err
 	}
+	user.TimeZone = timeZone
 
-	err = u.userRepo.Create(ctx, user)
+	// パスワードはハッシュ化して保存する
+	user.PasswordHash, err = auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, u.auditRepo, userAudit(user, domain.AuditActionUserRegistered, u.clock.Now()))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -52,75 +102,80 @@ func (u *userUsecase) createUser(username, email string) (*domain.User, error) {
 		ID:           domain.UserID(newUUID.String()),
 		Username:     username,
 		Email:        email,
+		Notification: domain.DefaultNotificationSettings(),
 		MorningCalls: []domain.MorningCall{},
 		RelatedUsers: []domain.RelatedUser{},
 	}, nil
 }
 
-func (u *userUsecase) Login(ctx context.Context, email string) (*domain.User, error) {
-	user, err := u.userRepo.FindByEmail(ctx, email)
+func (u *userUsecase) ListFriends(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	relationships, err := u.relationships.ListFriends(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return user, nil
+	// フレンド状態のRelationshipを相手ユーザーの情報に変換して返す
+	return u.relatedUsers(ctx, userID, relationships)
 }
 
-func (u *userUsecase) ListFriends(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error) {
-	user, err := u.userRepo.FindByID(ctx, userID)
-	if err != nil {
+func (u *userUsecase) ListBlockedUsers(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
 		return nil, err
 	}
 
-	// フレンド状態のRelatedUserのみを返す
-	var friends []domain.RelatedUser
-	for _, relatedUser := range user.RelatedUsers {
-		if relatedUser.Status == domain.RelatedUserStatusApproved {
-			friends = append(friends, relatedUser)
-		}
+	relationships, err := u.relationships.ListBlocked(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return friends, nil
+	return u.relatedUsers(ctx, userID, relationships)
 }
 
-func (u *userUsecase) ApplyFriend(ctx context.Context, userID, targetUserID domain.UserID) error {
-	user, err := u.userRepo.FindByID(ctx, userID)
-	if err != nil {
-		return err
+func (u *userUsecase) ListFriendRequests(ctx context.Context, userID domain.UserID) ([]domain.RelatedUser, error) {
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
 	}
 
-	targetUser, err := u.userRepo.FindByID(ctx, targetUserID)
+	relationships, err := u.relationships.ListPendingRequests(ctx, userID)
 	if err != nil {
-		return err
-	}
-
-	// 申請可能かチェック
-	if ng := user.CanAddFriend(targetUserID); ng.IsNG() {
-		return fmt.Errorf("friend application failed: %s", ng.String())
+		return nil, err
 	}
 
-	// 申請者側にpending状態で追加
-	user.RelatedUsers = append(user.RelatedUsers, domain.RelatedUser{
-		ID:     targetUserID,
-		Status: domain.RelatedUserStatusPending,
-	})
-
-	// 被申請者側にpending状態で追加
-	targetUser.RelatedUsers = append(targetUser.RelatedUsers, domain.RelatedUser{
-		ID:     userID,
-		Status: domain.RelatedUserStatusPending,
-	})
+	// 申請者の情報を返す。承認・拒否はこのIDを指定して行う
+	return u.relatedUsers(ctx, userID, relationships)
+}
 
-	// 両方のユーザーを更新
-	if err := u.userRepo.Update(ctx, user); err != nil {
-		return err
+// relatedUsers はRelationshipを相手ユーザーの情報に変換します
+func (u *userUsecase) relatedUsers(ctx context.Context, userID domain.UserID, relationships []*domain.Relationship) ([]domain.RelatedUser, error) {
+	users := make([]domain.RelatedUser, 0, len(relationships))
+	for _, rel := range relationships {
+		other, err := u.userRepo.FindByID(ctx, rel.GetOtherUserID(userID))
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, domain.RelatedUser{
+			ID:       other.ID,
+			Username: other.Username,
+			Email:    other.Email,
+			Status:   domain.RelatedUserStatus(rel.Status),
+		})
 	}
 
-	if err := u.userRepo.Update(ctx, targetUser); err != nil {
-		return err
-	}
+	return users, nil
+}
 
-	return nil
+func (u *userUsecase) ApplyFriend(ctx context.Context, userID, targetUserID domain.UserID) error {
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		relationship, err := u.relationships.SendRequest(ctx, userID, targetUserID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, u.auditRepo, relationshipAudit(userID, domain.AuditActionFriendRequested, relationship, ""))
+	})
 }
 
 func (u *userUsecase) ReactFriendApply(ctx context.Context, userID, applyingUserID domain.UserID, approve bool) (domain.RelatedUserStatus, error) {
@@ -129,109 +184,268 @@ func (u *userUsecase) ReactFriendApply(ctx context.Context, userID, applyingUser
 		return "", err
 	}
 
-	applyingUser, err := u.userRepo.FindByID(ctx, applyingUserID)
+	if _, err := u.userRepo.FindByID(ctx, applyingUserID); err != nil {
+		return "", err
+	}
+
+	existing, err := u.relationshipRepo.FindAllByUsers(ctx, userID, applyingUserID)
 	if err != nil {
 		return "", err
 	}
 
 	// 承認可能かチェック
-	if ng := user.CanApproveFriend(applyingUserID); ng.IsNG() {
-		return "", fmt.Errorf("friend approval failed: %s", ng.String())
+	if ng := user.CanApproveFriend(applyingUserID, existing); ng.IsNG() {
+		return "", ngReasonError(ng)
 	}
 
-	var newStatus domain.RelatedUserStatus
-	if approve {
-		newStatus = domain.RelatedUserStatusApproved
-	} else {
-		newStatus = domain.RelatedUserStatusRejected
+	request, err := u.relationshipRepo.FindByUsers(ctx, applyingUserID, userID)
+	if err != nil {
+		return "", err
 	}
 
-	// 両方のユーザーのRelatedUsersを更新
-	for i, relatedUser := range user.RelatedUsers {
-		if relatedUser.ID == applyingUserID {
-			if approve {
-				user.RelatedUsers[i].Status = domain.RelatedUserStatusApproved
-			} else {
-				// 拒否の場合はステータスを変更
-				user.RelatedUsers[i].Status = domain.RelatedUserStatusRejected
-			}
-			break
+	var relationship *domain.Relationship
+	err = u.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		action := domain.AuditActionFriendApproved
+		if approve {
+			relationship, err = u.relationships.Approve(ctx, userID, request.ID)
+		} else {
+			action = domain.AuditActionFriendRejected
+			relationship, err = u.relationships.Reject(ctx, userID, request.ID)
 		}
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, u.auditRepo, relationshipAudit(userID, action, relationship, request.Status))
+	})
+	if err != nil {
+		return "", err
 	}
 
-	for i, relatedUser := range applyingUser.RelatedUsers {
-		if relatedUser.ID == userID {
-			if approve {
-				applyingUser.RelatedUsers[i].Status = domain.RelatedUserStatusApproved
-			} else {
-				// 拒否の場合はステータスを変更
-				applyingUser.RelatedUsers[i].Status = domain.RelatedUserStatusRejected
-			}
-			break
+	return domain.RelatedUserStatus(relationship.Status), nil
+}
+
+func (u *userUsecase) BlockFriend(ctx context.Context, userID, blockUserID domain.UserID) error {
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		relationship, err := u.relationships.Block(ctx, userID, blockUserID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, u.auditRepo, relationshipAudit(userID, domain.AuditActionUserBlocked, relationship, "")); err != nil {
+			return err
+		}
+		return u.cancelMorningCallsBetween(ctx, userID, blockUserID, relationship.UpdatedAt)
+	})
+}
+
+func (u *userUsecase) CancelFriendRequest(ctx context.Context, userID, targetUserID domain.UserID) error {
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		relationship, err := u.relationships.CancelRequest(ctx, userID, targetUserID)
+		if err != nil {
+			return err
 		}
+		return recordAudit(ctx, u.auditRepo, relationshipAudit(userID, domain.AuditActionFriendRequestCancelled, relationship, domain.RelationshipStatusPending))
+	})
+}
+
+func (u *userUsecase) Unfriend(ctx context.Context, userID, friendID domain.UserID) error {
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		relationship, err := u.relationships.Unfriend(ctx, userID, friendID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, u.auditRepo, relationshipAudit(userID, domain.AuditActionUnfriended, relationship, domain.RelationshipStatusApproved)); err != nil {
+			return err
+		}
+		return u.cancelMorningCallsBetween(ctx, userID, friendID, relationship.UpdatedAt)
+	})
+}
+
+// ExpireFriendRequests は ttl 以上承認待ちのままの申請を期限切れにします
+// 期限切れになった申請は、申請者から改めて申請できます
+func (u *userUsecase) ExpireFriendRequests(ctx context.Context, ttl time.Duration) error {
+	pending, err := u.relationshipRepo.FindByStatus(ctx, domain.RelationshipStatusPending)
+	if err != nil {
+		return err
 	}
 
-	// 両方のユーザーを更新
-	if err := u.userRepo.Update(ctx, user); err != nil {
-		return "", err
+	now := u.clock.Now()
+
+	var errs []error
+	for _, rel := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !rel.IsStale(ttl, now) {
+			continue
+		}
+		if err := rel.Expire(now); err != nil {
+			continue
+		}
+
+		// 1件ずつ処理し、取得後に承認・拒否された申請はそちらを優先する
+		err := u.txManager.Do(ctx, func(ctx context.Context) error {
+			if err := u.relationshipRepo.Update(ctx, rel); err != nil {
+				return err
+			}
+			return recordAudit(ctx, u.auditRepo, relationshipAudit(domain.SystemActor, domain.AuditActionFriendRequestExpired, rel, domain.RelationshipStatusPending))
+		})
+		if err != nil && !apperrors.IsConflictError(err) && !apperrors.IsNotFoundError(err) {
+			errs = append(errs, fmt.Errorf("failed to expire friend request %s: %w", rel.ID, err))
+		}
 	}
 
-	if err := u.userRepo.Update(ctx, applyingUser); err != nil {
-		return "", err
+	return errors.Join(errs...)
+}
+
+// cancelMorningCallsBetween はフレンド関係が終了した2人の間のモーニングコールを止めます
+// 未配信・スヌーズ中のモーニングコールは削除し、有効な繰り返し設定は終了します
+// 削除したモーニングコールは、復元期間内に再びフレンドになれば復元できます
+func (u *userUsecase) cancelMorningCallsBetween(ctx context.Context, userID, otherUserID domain.UserID, now time.Time) error {
+	for _, pair := range [][2]domain.UserID{{userID, otherUserID}, {otherUserID, userID}} {
+		senderID, receiverID := pair[0], pair[1]
+
+		calls, err := u.morningCallRepo.ListBySenderID(ctx, senderID)
+		if err != nil {
+			return err
+		}
+		for _, mc := range calls {
+			if mc.ReceiverID != receiverID {
+				continue
+			}
+			if mc.Status != domain.MorningCallStatusScheduled && mc.Status != domain.MorningCallStatusSnoozed {
+				continue
+			}
+
+			before := mc.Status
+			mc.SoftDelete(now)
+			if err := u.morningCallRepo.Update(ctx, mc); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, u.auditRepo, morningCallAudit(userID, domain.AuditActionMorningCallDeleted, mc, before, now)); err != nil {
+				return err
+			}
+		}
+
+		series, err := u.seriesRepo.ListBySenderID(ctx, senderID)
+		if err != nil {
+			return err
+		}
+		for _, s := range series {
+			if s.ReceiverID != receiverID || s.Status != domain.MorningCallSeriesStatusActive {
+				continue
+			}
+			before := s.Status
+			s.Cancel(now)
+			if err := u.seriesRepo.Update(ctx, s); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, u.auditRepo, seriesAudit(userID, domain.AuditActionMorningCallSeriesCancelled, s, before, now)); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
 
-	return newStatus, nil
+func (u *userUsecase) UnblockUser(ctx context.Context, userID, blockedUserID domain.UserID) error {
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		block, err := u.relationships.Unblock(ctx, userID, blockedUserID)
+		if err != nil {
+			return err
+		}
+		// ブロックは削除されるため、解除後のステータスは空になる
+		entry := relationshipAudit(userID, domain.AuditActionUserUnblocked, block, block.Status)
+		entry.AfterStatus = ""
+		entry.OccurredAt = u.clock.Now()
+		return recordAudit(ctx, u.auditRepo, entry)
+	})
 }
 
-func (u *userUsecase) BlockFriend(ctx context.Context, userID, blockUserID domain.UserID) error {
-	user, err := u.userRepo.FindByID(ctx, userID)
+func (u *userUsecase) GetUser(ctx context.Context, userID domain.UserID) (*domain.User, error) {
+	return u.userRepo.FindByID(ctx, userID)
+}
+
+func (u *userUsecase) UpdateNotificationSettings(ctx context.Context, userID domain.UserID, settings domain.NotificationSettings, version int) (*domain.User, error) {
+	user, err := u.findUserForUpdate(ctx, userID, version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	blockUser, err := u.userRepo.FindByID(ctx, blockUserID)
+	// 設定内容の妥当性チェック
+	if ng := settings.Validate(); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+
+	user.Notification = settings
+	if err := u.updateUser(ctx, user, domain.AuditActionNotificationSettingsUpdated); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *userUsecase) UpdateTimeZone(ctx context.Context, userID domain.UserID, timeZone string, version int) (*domain.User, error) {
+	user, err := u.findUserForUpdate(ctx, userID, version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// ブロック可能かチェック
-	if ng := user.CanBlockUser(blockUserID); ng.IsNG() {
-		return fmt.Errorf("block user failed: %s", ng.String())
+	// IANA タイムゾーン名のみ受け付ける
+	if !validation.ValidateTimeZone(timeZone) {
+		return nil, apperrors.ValidationError("time_zone", domain.NGReasonInvalidTimeZone.String())
 	}
 
-	// ユーザーのRelatedUsersを更新（ブロック状態に変更または追加）
-	found := false
-	for i, relatedUser := range user.RelatedUsers {
-		if relatedUser.ID == blockUserID {
-			user.RelatedUsers[i].Status = domain.RelatedUserStatusBlocked
-			found = true
-			break
-		}
+	user.TimeZone = timeZone
+	if err := u.updateUser(ctx, user, domain.AuditActionTimeZoneUpdated); err != nil {
+		return nil, err
 	}
 
-	if !found {
-		user.RelatedUsers = append(user.RelatedUsers, domain.RelatedUser{
-			ID:     blockUserID,
-			Status: domain.RelatedUserStatusBlocked,
-		})
+	return user, nil
+}
+
+func (u *userUsecase) UpdateReceiverPreferences(ctx context.Context, userID domain.UserID, prefs domain.ReceiverPreferences, version int) (*domain.User, error) {
+	user, err := u.findUserForUpdate(ctx, userID, version)
+	if err != nil {
+		return nil, err
 	}
 
-	// ブロックされた側のRelatedUsersから削除
-	for i, relatedUser := range blockUser.RelatedUsers {
-		if relatedUser.ID == userID {
-			blockUser.RelatedUsers = append(blockUser.RelatedUsers[:i], blockUser.RelatedUsers[i+1:]...)
-			break
-		}
+	// 設定内容の妥当性チェック
+	if ng := prefs.Validate(); ng.IsNG() {
+		return nil, ngReasonError(ng)
+	}
+	if slices.Contains(prefs.AllowedCallers, userID) || slices.Contains(prefs.DeniedCallers, userID) {
+		return nil, ngReasonError(domain.NGReasonSelfOperation)
 	}
 
-	// 両方のユーザーを更新
-	if err := u.userRepo.Update(ctx, user); err != nil {
-		return err
+	user.Preferences = prefs
+	if err := u.updateUser(ctx, user, domain.AuditActionReceiverPreferencesUpdated); err != nil {
+		return nil, err
 	}
 
-	if err := u.userRepo.Update(ctx, blockUser); err != nil {
-		return err
+	return user, nil
+}
+
+// updateUser はユーザーを更新し、同じトランザクションで監査ログを記録します
+func (u *userUsecase) updateUser(ctx context.Context, user *domain.User, action domain.AuditAction) error {
+	return u.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, u.auditRepo, userAudit(user, action, u.clock.Now()))
+	})
+}
+
+// findUserForUpdate はユーザーを取得し、クライアントが指定したバージョンを更新の前提条件として設定します
+// version が 0 の場合は取得時点のバージョンを前提とします
+func (u *userUsecase) findUserForUpdate(ctx context.Context, userID domain.UserID, version int) (*domain.User, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if version != 0 {
+		user.Version = version
+	}
+	return user, nil
 }
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"morning-call/internal/domain"
+	apperrors "morning-call/internal/shared/errors"
+)
+
+// クライアントが取得した後に更新されたユーザーは上書きしない
+func TestUpdateTimeZone_ChecksVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int
+		conflict bool
+	}{
+		{name: "without a precondition", version: 0},
+		{name: "current version", version: 2},
+		{name: "stale version", version: 1, conflict: true},
+		{name: "future version", version: 3, conflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(time.Now())
+			env.createUser(t, &domain.User{ID: "alice", TimeZone: "UTC"})
+			users := env.userUsecase()
+
+			// 他のクライアントの更新でバージョン 2 になっている
+			if _, err := users.UpdateTimeZone(ctx, "alice", "Europe/London", 1); err != nil {
+				t.Fatalf("UpdateTimeZone: %v", err)
+			}
+
+			updated, err := users.UpdateTimeZone(ctx, "alice", "Asia/Tokyo", tt.version)
+			stored, findErr := env.userRepo.FindByID(ctx, "alice")
+			if findErr != nil {
+				t.Fatalf("FindByID: %v", findErr)
+			}
+			audits, auditErr := env.auditRepo.ListByUser(ctx, "alice", 10)
+			if auditErr != nil {
+				t.Fatalf("ListByUser: %v", auditErr)
+			}
+
+			if tt.conflict {
+				if !apperrors.IsConflictError(err) {
+					t.Errorf("UpdateTimeZone error = %v, want a conflict", err)
+				}
+				if stored.TimeZone != "Europe/London" || stored.Version != 2 {
+					t.Errorf("stored = %s v%d, want it left at Europe/London v2", stored.TimeZone, stored.Version)
+				}
+				if len(audits) != 1 {
+					t.Errorf("audit entries = %d, want only the first update", len(audits))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdateTimeZone: %v", err)
+			}
+			if updated.Version != 3 || stored.TimeZone != "Asia/Tokyo" || stored.Version != 3 {
+				t.Errorf("updated v%d, stored = %s v%d, want Asia/Tokyo v3", updated.Version, stored.TimeZone, stored.Version)
+			}
+			if len(audits) != 2 {
+				t.Errorf("audit entries = %d, want 2", len(audits))
+			}
+		})
+	}
+}
+
+// フレンド解除で止めた繰り返し設定も、モーニングコールと同様に監査ログに残す
+func TestUnfriend_AuditsCancelledSeries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	env := newTestEnv(now)
+	env.addFriends(t, domain.ReceiverPreferences{})
+	series := newDailySeries(t, env, env.seriesUsecase(), time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC))
+	calls, err := env.morningCallRepo.ListBySeriesID(ctx, series.ID)
+	if err != nil || len(calls) == 0 {
+		t.Fatalf("ListBySeriesID = %d calls, %v, want the materialized occurrences", len(calls), err)
+	}
+
+	if err := env.userUsecase().Unfriend(ctx, "receiver", "sender"); err != nil {
+		t.Fatalf("Unfriend: %v", err)
+	}
+
+	cancelled, err := env.seriesRepo.FindByID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if cancelled.Status != domain.MorningCallSeriesStatusCancelled {
+		t.Fatalf("series status = %s, want %s", cancelled.Status, domain.MorningCallSeriesStatusCancelled)
+	}
+
+	entries, err := env.auditRepo.ListByEntity(ctx, string(series.ID), 10)
+	if err != nil {
+		t.Fatalf("ListByEntity: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("series audit entries = %d, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != domain.AuditActionMorningCallSeriesCancelled || entry.EntityType != domain.AuditEntitySeries || entry.ActorID != "receiver" ||
+		entry.BeforeStatus != string(domain.MorningCallSeriesStatusActive) || entry.AfterStatus != string(domain.MorningCallSeriesStatusCancelled) {
+		t.Errorf("series audit entry = %+v", *entry)
+	}
+
+	for _, mc := range calls {
+		entries, err := env.auditRepo.ListByEntity(ctx, string(mc.ID), 10)
+		if err != nil {
+			t.Fatalf("ListByEntity: %v", err)
+		}
+		if len(entries) == 0 || entries[0].Action != domain.AuditActionMorningCallDeleted {
+			t.Errorf("morning call %s audit entries = %v, want the deletion first", mc.ID, entries)
+		}
+	}
+}
+
+// 有効期限を過ぎた承認待ちの申請だけを期限切れにする
+func TestExpireFriendRequests(t *testing.T) {
+	ctx := context.Background()
+	const ttl = 7 * 24 * time.Hour
+	env := newTestEnv(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	for _, id := range []domain.UserID{"alice", "bob", "carol", "dave"} {
+		env.createUser(t, &domain.User{ID: id, TimeZone: "UTC"})
+	}
+	users := env.userUsecase()
+
+	for _, receiverID := range []domain.UserID{"bob", "carol"} {
+		if err := users.ApplyFriend(ctx, "alice", receiverID); err != nil {
+			t.Fatalf("ApplyFriend(%s): %v", receiverID, err)
+		}
+	}
+	if _, err := users.ReactFriendApply(ctx, "carol", "alice", true); err != nil {
+		t.Fatalf("ReactFriendApply: %v", err)
+	}
+	// 後から送った申請は、先の申請の期限が切れた時点ではまだ有効
+	env.clock.Advance(time.Hour)
+	if err := users.ApplyFriend(ctx, "dave", "alice"); err != nil {
+		t.Fatalf("ApplyFriend(dave): %v", err)
+	}
+
+	status := func(requesterID, receiverID domain.UserID) domain.RelationshipStatus {
+		t.Helper()
+		rels, err := env.relationshipRepo.FindAllByUsers(ctx, requesterID, receiverID)
+		if err != nil || len(rels) != 1 {
+			t.Fatalf("FindAllByUsers(%s, %s) = %v, %v, want one relationship", requesterID, receiverID, rels, err)
+		}
+		return rels[0].Status
+	}
+
+	// 有効期限の直前はまだ期限切れにしない
+	env.clock.Advance(ttl - time.Hour - time.Second)
+	if err := users.ExpireFriendRequests(ctx, ttl); err != nil {
+		t.Fatalf("ExpireFriendRequests before the TTL: %v", err)
+	}
+	if got := status("alice", "bob"); got != domain.RelationshipStatusPending {
+		t.Fatalf("status before the TTL = %s, want %s", got, domain.RelationshipStatusPending)
+	}
+
+	env.clock.Advance(time.Second)
+	if err := users.ExpireFriendRequests(ctx, ttl); err != nil {
+		t.Fatalf("ExpireFriendRequests: %v", err)
+	}
+	if got := status("alice", "bob"); got != domain.RelationshipStatusExpired {
+		t.Errorf("alice -> bob status = %s, want %s", got, domain.RelationshipStatusExpired)
+	}
+	if got := status("alice", "carol"); got != domain.RelationshipStatusApproved {
+		t.Errorf("alice -> carol status = %s, want it left %s", got, domain.RelationshipStatusApproved)
+	}
+	if got := status("dave", "alice"); got != domain.RelationshipStatusPending {
+		t.Errorf("dave -> alice status = %s, want it left %s", got, domain.RelationshipStatusPending)
+	}
+
+	entries, err := env.auditRepo.ListByUser(ctx, "bob", 10)
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+	if len(entries) == 0 || entries[0].Action != domain.AuditActionFriendRequestExpired || !entries[0].OccurredAt.Equal(env.clock.Now()) {
+		t.Errorf("latest bob audit entry = %v, want the expiry at %v", entries, env.clock.Now())
+	}
+}
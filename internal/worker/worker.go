@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of background work executed periodically
+type Job func(ctx context.Context) error
+
+// Run executes job every interval until ctx is cancelled
+// Errors are logged and do not stop the loop
+func Run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("worker %s started (interval %s)", name, interval)
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("worker %s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("worker %s stopped", name)
+			return
+		case <-ticker.C:
+		}
+	}
+}